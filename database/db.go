@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
@@ -40,35 +41,26 @@ func InitDB() {
 	if err != nil {
 		log.Fatal("Error verifying connection to database:", err)
 	}
+	setHealth(nil)
 
-	// Create employees table if it doesn't exist
-	createTableQuery := `
-	CREATE TABLE IF NOT EXISTS m_employee (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		employee_code VARCHAR(20),
-		prefix_name VARCHAR(50) NOT NULL,
-		first_name VARCHAR(100) NOT NULL,
-		last_name VARCHAR(100) NOT NULL,
-		nickname VARCHAR(50),
-		email VARCHAR(150),
-		phone_number VARCHAR(50),
-		gender SMALLINT DEFAULT 0,
-		birth_date DATE,
-		hire_date DATE,
-		department VARCHAR(150),
-		position VARCHAR(150),
-		employment_type SMALLINT,
-		is_active BOOLEAN DEFAULT TRUE,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	)`
+	// Recycle connections periodically so ones left dead by a database restart are replaced
+	connMaxLifetime := 30 * time.Minute
+	if value := os.Getenv("DB_CONN_MAX_LIFETIME"); value != "" {
+		connMaxLifetime, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatal("Invalid DB_CONN_MAX_LIFETIME:", err)
+		}
+	}
+	DB.SetConnMaxLifetime(connMaxLifetime)
 
-	_, err = DB.Exec(createTableQuery)
-	if err != nil {
-		log.Fatal("Error creating table:", err)
+	// Bring the schema up to date, applying only migrations that have not run yet
+	if err := Migrate(DB); err != nil {
+		log.Fatal("Error migrating database:", err)
 	}
 
-	log.Println("Database connection established and table created successfully")
+	checkSchema()
+
+	log.Println("Database connection established and migrations applied successfully")
 }
 
 // Close closes the database connection
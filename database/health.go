@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Health is the outcome of the most recent database ping
+type Health struct {
+	Healthy   bool
+	CheckedAt time.Time
+	Error     string
+}
+
+var (
+	healthMu sync.RWMutex
+	health   Health
+)
+
+// maxHealthPingTimeout bounds each background ping, so a hung connection is reported
+// well before the next tick when the interval is long
+const maxHealthPingTimeout = 5 * time.Second
+
+// Pinger is implemented by *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Status returns the outcome of the most recent database ping
+func Status() Health {
+	healthMu.RLock()
+	defer healthMu.RUnlock()
+	return health
+}
+
+// setHealth records a ping outcome and logs when the status changes
+func setHealth(err error) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+
+	wasHealthy := health.Healthy
+	health = Health{Healthy: err == nil, CheckedAt: time.Now()}
+	if err != nil {
+		health.Error = err.Error()
+	}
+
+	switch {
+	case wasHealthy && err != nil:
+		log.Println("Database became unhealthy:", err)
+	case !wasHealthy && err == nil:
+		log.Println("Database is healthy again")
+	}
+}
+
+// CheckHealth pings the database once, bounded by timeout, and records the outcome
+func CheckHealth(db Pinger, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	setHealth(db.PingContext(ctx))
+}
+
+// StartHealthMonitor pings the database every interval in the background until ctx is done.
+// Dead pooled connections are discarded by the pool and recycled via SetConnMaxLifetime.
+// An interval of zero or less disables it.
+func StartHealthMonitor(ctx context.Context, db Pinger, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	timeout := min(interval, maxHealthPingTimeout)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				CheckHealth(db, timeout)
+			}
+		}
+	}()
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakePinger struct {
+	err   error
+	calls atomic.Int32
+	// budget is the time left until the most recent ping's deadline
+	budget atomic.Int64
+}
+
+func (p *fakePinger) PingContext(ctx context.Context) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		p.budget.Store(int64(time.Until(deadline)))
+	}
+	p.calls.Add(1)
+	return p.err
+}
+
+func TestCheckHealthRecordsOutcome(t *testing.T) {
+	CheckHealth(&fakePinger{err: errors.New("connection refused")}, time.Second)
+	if status := Status(); status.Healthy || status.Error != "connection refused" || status.CheckedAt.IsZero() {
+		t.Errorf("after failed ping Status() = %+v, want unhealthy with the error", status)
+	}
+
+	CheckHealth(&fakePinger{}, time.Second)
+	if status := Status(); !status.Healthy || status.Error != "" {
+		t.Errorf("after successful ping Status() = %+v, want healthy", status)
+	}
+}
+
+func TestStartHealthMonitorDisabled(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		pinger := &fakePinger{}
+		// Must not panic the way time.NewTicker does for non-positive intervals
+		StartHealthMonitor(context.Background(), pinger, interval)
+		time.Sleep(10 * time.Millisecond)
+		if calls := pinger.calls.Load(); calls != 0 {
+			t.Errorf("interval %v: pinged %d times, want monitor disabled", interval, calls)
+		}
+	}
+}
+
+func TestStartHealthMonitorPings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pinger := &fakePinger{}
+	StartHealthMonitor(ctx, pinger, 5*time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for pinger.calls.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("monitor did not ping within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStartHealthMonitorPingTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A short interval bounds the ping by the interval itself
+	pinger := &fakePinger{}
+	StartHealthMonitor(ctx, pinger, 20*time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for pinger.calls.Load() < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("monitor did not ping within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if budget := time.Duration(pinger.budget.Load()); budget <= 0 || budget > 20*time.Millisecond {
+		t.Errorf("ping budget = %v, want at most the 20ms interval", budget)
+	}
+}
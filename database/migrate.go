@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migrations are SQL files named NNNN_description.sql, applied in order of their number
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the advisory lock key that keeps instances starting together from migrating at the same time
+const migrationLockID = 72700001
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// loadMigrations reads the migration files under migrations/ in fsys sorted by version
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	names, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0, len(names))
+	seen := make(map[int]string)
+	for _, path := range names {
+		name := strings.TrimSuffix(strings.TrimPrefix(path, "migrations/"), ".sql")
+		prefix, _, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("migration %s is not named NNNN_description.sql", path)
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, name, version)
+		}
+		seen[version] = name
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration{version: version, name: name, sql: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// Migrate applies every migration not yet recorded in schema_migrations, each in its own transaction.
+// Running it again is a no-op, so existing data is never touched by a restart.
+func Migrate(db *sql.DB) error {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	for _, m := range migrations {
+		applied, err := applyMigration(db, m)
+		if err != nil {
+			return fmt.Errorf("applying migration %s: %w", m.name, err)
+		}
+		if applied {
+			log.Println("Applied migration", m.name)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs one migration unless it has already been recorded, reporting whether it ran
+func applyMigration(db *sql.DB, m migration) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Check under the lock so a migration another instance just finished is not run twice
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, m.version).Scan(&exists)
+	if err != nil || exists {
+		return false, err
+	}
+
+	if _, err := tx.Exec(m.sql); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.version, m.name); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// SchemaVersion returns the highest applied migration version, 0 when none have run
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrationsEmbedded(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("migration %s has version %d, want %d so none is skipped", m.name, m.version, i+1)
+		}
+		if strings.TrimSpace(m.sql) == "" {
+			t.Errorf("migration %s is empty", m.name)
+		}
+	}
+}
+
+func TestLoadMigrationsOrdersByVersion(t *testing.T) {
+	migrations, err := loadMigrations(fstest.MapFS{
+		"migrations/10_later.sql":    {Data: []byte("SELECT 10")},
+		"migrations/0002_second.sql": {Data: []byte("SELECT 2")},
+		"migrations/0001_first.sql":  {Data: []byte("SELECT 1")},
+		"migrations/README.md":       {Data: []byte("not a migration")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, m := range migrations {
+		names = append(names, m.name)
+	}
+	if got := strings.Join(names, ","); got != "0001_first,0002_second,10_later" {
+		t.Errorf("order = %s, want by version number", got)
+	}
+	if migrations[0].sql != "SELECT 1" {
+		t.Errorf("sql = %q, want the file content", migrations[0].sql)
+	}
+}
+
+func TestLoadMigrationsRejectsBadFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		wantErr string
+	}{
+		{"duplicate version", fstest.MapFS{
+			"migrations/0001_first.sql": {},
+			"migrations/001_again.sql":  {},
+		}, "share version 1"},
+		{"no description", fstest.MapFS{"migrations/0001.sql": {}}, "is not named NNNN_description.sql"},
+		{"no number", fstest.MapFS{"migrations/add_column.sql": {}}, "is not named NNNN_description.sql"},
+	}
+	for _, tt := range tests {
+		_, err := loadMigrations(tt.files)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want one containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMigrateTwiceIsNoop(t *testing.T) {
+	state := &migrationState{applied: make(map[int64]bool)}
+	sql.Register("database-migrate-fake", migrationDriver{state})
+	db, err := sql.Open("database-migrate-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if state.ran != len(migrations) {
+		t.Errorf("first run applied %d migrations, want %d", state.ran, len(migrations))
+	}
+
+	state.ran = 0
+	if err := Migrate(db); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if state.ran != 0 {
+		t.Errorf("second run applied %d migrations, want none", state.ran)
+	}
+}
+
+// migrationState is the part of schema_migrations the fake driver keeps track of
+type migrationState struct {
+	mu      sync.Mutex
+	applied map[int64]bool
+	ran     int
+}
+
+type migrationDriver struct{ state *migrationState }
+
+func (d migrationDriver) Open(string) (driver.Conn, error) { return migrationConn(d), nil }
+
+type migrationConn struct{ state *migrationState }
+
+func (c migrationConn) Prepare(query string) (driver.Stmt, error) {
+	return migrationStmt{state: c.state, query: query}, nil
+}
+func (c migrationConn) Close() error              { return nil }
+func (c migrationConn) Begin() (driver.Tx, error) { return migrationTx{}, nil }
+
+type migrationTx struct{}
+
+func (migrationTx) Commit() error   { return nil }
+func (migrationTx) Rollback() error { return nil }
+
+type migrationStmt struct {
+	state *migrationState
+	query string
+}
+
+func (s migrationStmt) Close() error  { return nil }
+func (s migrationStmt) NumInput() int { return -1 }
+
+func (s migrationStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	switch {
+	case strings.Contains(s.query, "INSERT INTO schema_migrations"):
+		s.state.applied[args[0].(int64)] = true
+	case strings.Contains(s.query, "CREATE TABLE IF NOT EXISTS schema_migrations"),
+		strings.Contains(s.query, "pg_advisory_xact_lock"):
+	default:
+		s.state.ran++
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s migrationStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	return &existsRows{exists: s.state.applied[args[0].(int64)]}, nil
+}
+
+// existsRows answers the SELECT EXISTS check with a single boolean
+type existsRows struct {
+	exists bool
+	done   bool
+}
+
+func (r *existsRows) Columns() []string { return []string{"exists"} }
+func (r *existsRows) Close() error      { return nil }
+
+func (r *existsRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.exists
+	r.done = true
+	return nil
+}
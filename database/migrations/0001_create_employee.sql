@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS m_employee (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	employee_code VARCHAR(20),
+	prefix_name VARCHAR(50) NOT NULL,
+	first_name VARCHAR(100) NOT NULL,
+	last_name VARCHAR(100) NOT NULL,
+	nickname VARCHAR(50),
+	email VARCHAR(150),
+	phone_number VARCHAR(50),
+	gender SMALLINT DEFAULT 0,
+	birth_date DATE,
+	hire_date DATE,
+	department VARCHAR(150),
+	position VARCHAR(150),
+	employment_type SMALLINT,
+	is_active BOOLEAN DEFAULT TRUE,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Emails must be unique regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS ux_employee_email_lower
+	ON m_employee (lower(email)) WHERE email IS NOT NULL AND email <> '';
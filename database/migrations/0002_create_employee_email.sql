@@ -0,0 +1,12 @@
+-- Additional addresses per employee, allowing one primary address per type
+CREATE TABLE IF NOT EXISTS m_employee_email (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	employee_id UUID NOT NULL REFERENCES m_employee(id) ON DELETE CASCADE,
+	email VARCHAR(150) NOT NULL,
+	email_type VARCHAR(20) NOT NULL CHECK (email_type IN ('company', 'personal', 'other')),
+	is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_employee_email_primary
+	ON m_employee_email (employee_id, email_type) WHERE is_primary;
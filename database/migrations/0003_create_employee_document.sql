@@ -0,0 +1,16 @@
+-- Documents attached to an employee, soft-deleted via deleted_at
+CREATE TABLE IF NOT EXISTS m_employee_document (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	employee_id UUID NOT NULL REFERENCES m_employee(id) ON DELETE CASCADE,
+	document_type VARCHAR(50) NOT NULL,
+	file_name VARCHAR(255) NOT NULL,
+	content_type VARCHAR(100) NOT NULL,
+	size_bytes BIGINT NOT NULL,
+	content BYTEA NOT NULL,
+	uploaded_by VARCHAR(150),
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS ix_employee_document_employee
+	ON m_employee_document (employee_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+-- Convert timestamp columns of tables created before they were time zone aware.
+-- Existing values are interpreted in the session time zone they were written in.
+ALTER TABLE m_employee
+	ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+	ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE m_employee_email
+	ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE m_employee_document
+	ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+	ALTER COLUMN deleted_at TYPE TIMESTAMPTZ;
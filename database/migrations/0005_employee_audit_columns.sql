@@ -0,0 +1,4 @@
+-- Who created and last changed each employee, taken from the authenticated user
+ALTER TABLE m_employee
+	ADD COLUMN IF NOT EXISTS created_by VARCHAR(150),
+	ADD COLUMN IF NOT EXISTS updated_by VARCHAR(150);
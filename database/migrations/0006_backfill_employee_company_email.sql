@@ -0,0 +1,11 @@
+-- Record each employee's email as their primary company address, so addresses given
+-- when the employee was created are listed and kept in sync by the emails API
+INSERT INTO m_employee_email (employee_id, email, email_type, is_primary)
+SELECT e.id, e.email, 'company', TRUE
+FROM m_employee e
+WHERE e.email IS NOT NULL AND e.email <> ''
+	AND NOT EXISTS (
+		SELECT 1 FROM m_employee_email m
+		WHERE m.employee_id = e.id
+			AND ((m.email_type = 'company' AND m.is_primary) OR lower(m.email) = lower(e.email))
+	);
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// RequiredTables lists the tables the handlers query and expect to exist
+var RequiredTables = []string{
+	"m_employee",
+	"m_employee_email",
+	"m_employee_document",
+}
+
+// MissingTables returns the tables from the list that do not exist in the current schema
+func MissingTables(db *sql.DB, tables []string) ([]string, error) {
+	rows, err := db.Query(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_name = ANY($1)`, pq.Array(tables))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool, len(tables))
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		found[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, table := range tables {
+		if !found[table] {
+			missing = append(missing, table)
+		}
+	}
+
+	return missing, nil
+}
+
+// checkSchema verifies RequiredTables exist. SCHEMA_CHECK controls the outcome:
+// "fail" (the default) stops startup, "warn" only logs and "off" skips the check.
+func checkSchema() {
+	mode := os.Getenv("SCHEMA_CHECK")
+	if mode == "" {
+		mode = "fail"
+	}
+	if mode == "off" {
+		return
+	}
+
+	missing, err := MissingTables(DB, RequiredTables)
+	if err != nil {
+		log.Fatal("Error checking database schema:", err)
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	message := "Database is missing required tables: " + strings.Join(missing, ", ")
+	if mode == "warn" {
+		log.Println("Warning:", message)
+		return
+	}
+	log.Fatal(message)
+}
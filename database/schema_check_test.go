@@ -0,0 +1,105 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// schemaDriver answers the information_schema lookup of MissingTables with existing tables
+type schemaDriver struct {
+	existing []string
+	err      error
+}
+
+func (d schemaDriver) Open(string) (driver.Conn, error) { return schemaConn(d), nil }
+
+type schemaConn schemaDriver
+
+func (c schemaConn) Prepare(query string) (driver.Stmt, error) { return schemaStmt(c), nil }
+func (c schemaConn) Close() error                              { return nil }
+func (c schemaConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type schemaStmt schemaDriver
+
+func (s schemaStmt) Close() error  { return nil }
+func (s schemaStmt) NumInput() int { return -1 }
+func (s schemaStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+// Query filters the existing tables by the Postgres array literal passed as $1
+func (s schemaStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	wanted := strings.Split(strings.Trim(args[0].(string), "{}"), ",")
+	rows := &tableRows{}
+	for _, table := range s.existing {
+		for _, name := range wanted {
+			if strings.Trim(name, `"`) == table {
+				rows.names = append(rows.names, table)
+			}
+		}
+	}
+	return rows, nil
+}
+
+type tableRows struct{ names []string }
+
+func (r *tableRows) Columns() []string { return []string{"table_name"} }
+func (r *tableRows) Close() error      { return nil }
+
+func (r *tableRows) Next(dest []driver.Value) error {
+	if len(r.names) == 0 {
+		return io.EOF
+	}
+	dest[0], r.names = r.names[0], r.names[1:]
+	return nil
+}
+
+func TestMissingTables(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		want     []string
+	}{
+		{"all present", []string{"m_employee", "m_employee_email", "m_employee_document", "schema_migrations"}, nil},
+		{"some missing", []string{"m_employee_email"}, []string{"m_employee", "m_employee_document"}},
+		{"empty schema", nil, RequiredTables},
+	}
+	for i, tt := range tests {
+		driverName := "database-schema-fake-" + string(rune('a'+i))
+		sql.Register(driverName, schemaDriver{existing: tt.existing})
+		db, err := sql.Open(driverName, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		missing, err := MissingTables(db, RequiredTables)
+		db.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(missing, tt.want) {
+			t.Errorf("%s: missing = %v, want %v in RequiredTables order", tt.name, missing, tt.want)
+		}
+	}
+}
+
+func TestMissingTablesQueryError(t *testing.T) {
+	sql.Register("database-schema-fake-error", schemaDriver{err: errors.New("permission denied")})
+	db, err := sql.Open("database-schema-fake-error", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := MissingTables(db, RequiredTables); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("error = %v, want the query error", err)
+	}
+}
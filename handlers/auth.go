@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"backend/middleware"
+)
+
+// AuthEnabled reports whether requests are authenticated; it is turned on when JWT_SECRET is set
+var AuthEnabled bool
+
+// UserIDFromContext returns the ID of the user who authenticated the request, if any
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	return middleware.UserID(ctx)
+}
+
+// RequestIDFromContext returns the ID the RequestID middleware assigned to the request, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	return middleware.RequestIDFromContext(ctx)
+}
+
+// requestID returns the request ID already set on the response, empty if there is none
+func requestID(w http.ResponseWriter) string {
+	return w.Header().Get(middleware.RequestIDHeader)
+}
+
+// requireUser returns the user a change is attributed to. With authentication enabled
+// a request without a user is answered with 401 and ok is false; without it the
+// user is empty, which is stored as NULL.
+func requireUser(w http.ResponseWriter, r *http.Request) (userID string, ok bool) {
+	if userID, ok := UserIDFromContext(r.Context()); ok {
+		return userID, true
+	}
+	if AuthEnabled {
+		writeJSONError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "A bearer token is required")
+		return "", false
+	}
+	return "", true
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+)
+
+// Cohort is the number of active employees who started on the same date
+type Cohort struct {
+	HireDate string `json:"hire_date"`
+	Count    int    `json:"count"`
+}
+
+// GetEmployeeCohort godoc
+// @Summary Get employees hired on a date
+// @Description Get active employees whose hire date falls on the given date
+// @Tags employee
+// @Produce json
+// @Param date query string true "Hire date (YYYY-MM-DD)"
+// @Success 200 {array} Employee
+// @Failure 400 {object} ErrorResponse "Missing or invalid date"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 500 {object} ErrorResponse "Error retrieving employees"
+// @Security BearerAuth
+// @Router /employees/cohort [get]
+func GetEmployeeCohort(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r, "date") {
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "date is required")
+		return
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "date must be in YYYY-MM-DD format")
+		return
+	}
+
+	query := `SELECT ` + employeeColumns + ` FROM m_employee
+			  WHERE is_active = TRUE AND hire_date = $1
+			  ORDER BY first_name, last_name, id`
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	rows, err := DB.QueryContext(ctx, query, date)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving employees")
+		return
+	}
+	defer rows.Close()
+
+	employees := []Employee{}
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			writeDBError(w, r, err, "Error retrieving employees")
+			return
+		}
+		employees = append(employees, employee)
+	}
+	if err := rows.Err(); err != nil {
+		writeDBError(w, r, err, "Error retrieving employees")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, employees)
+}
+
+// GetEmployeeCohorts godoc
+// @Summary Summarize hire-date cohorts
+// @Description Get the number of active employees hired on each date, newest first
+// @Tags employee
+// @Produce json
+// @Success 200 {array} Cohort
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 500 {object} ErrorResponse "Error retrieving cohorts"
+// @Security BearerAuth
+// @Router /employees/cohorts [get]
+func GetEmployeeCohorts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r) {
+		return
+	}
+
+	query := `SELECT hire_date, COUNT(*) FROM m_employee
+			  WHERE is_active = TRUE AND hire_date IS NOT NULL
+			  GROUP BY hire_date
+			  ORDER BY hire_date DESC`
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	rows, err := DB.QueryContext(ctx, query)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving cohorts")
+		return
+	}
+	defer rows.Close()
+
+	cohorts := []Cohort{}
+	for rows.Next() {
+		var cohort Cohort
+		var hireDate time.Time
+		if err := rows.Scan(&hireDate, &cohort.Count); err != nil {
+			writeDBError(w, r, err, "Error retrieving cohorts")
+			return
+		}
+		cohort.HireDate = hireDate.Format("2006-01-02")
+		cohorts = append(cohorts, cohort)
+	}
+	if err := rows.Err(); err != nil {
+		writeDBError(w, r, err, "Error retrieving cohorts")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, cohorts)
+}
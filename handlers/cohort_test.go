@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetEmployeeCohort(t *testing.T) {
+	var queryArgs []driver.Value
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		queryArgs = args
+		return fakeResult{columns: employeeColumnNames, rows: [][]driver.Value{employeeRow(testEmployeeID)}}
+	})
+
+	w := httptest.NewRecorder()
+	GetEmployeeCohort(w, httptest.NewRequest(http.MethodGet, "/api/employees/cohort?date=2024-03-01", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	if !reflect.DeepEqual(queryArgs, []driver.Value{"2024-03-01"}) {
+		t.Errorf("query args = %v, want the date", queryArgs)
+	}
+	var employees []Employee
+	json.Unmarshal(w.Body.Bytes(), &employees)
+	if len(employees) != 1 || employees[0].ID != testEmployeeID || employees[0].HireDate != "2024-03-01" {
+		t.Errorf("employees = %+v", employees)
+	}
+}
+
+func TestGetEmployeeCohortRejectsDate(t *testing.T) {
+	for _, query := range []string{"", "?date=", "?date=01/03/2024", "?date=2024-02-30"} {
+		w := httptest.NewRecorder()
+		GetEmployeeCohort(w, httptest.NewRequest(http.MethodGet, "/api/employees/cohort"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: status = %d, want 400", query, w.Code)
+		} else if detail := decodeError(t, w); detail.Code != "INVALID_QUERY_PARAMETER" {
+			t.Errorf("%q: code = %q", query, detail.Code)
+		}
+	}
+}
+
+func TestGetEmployeeCohorts(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		return fakeResult{columns: []string{"hire_date", "count"}, rows: [][]driver.Value{
+			{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), int64(3)},
+			{time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC), int64(1)},
+		}}
+	})
+
+	w := httptest.NewRecorder()
+	GetEmployeeCohorts(w, httptest.NewRequest(http.MethodGet, "/api/employees/cohorts", nil))
+
+	var cohorts []Cohort
+	if err := json.Unmarshal(w.Body.Bytes(), &cohorts); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	want := []Cohort{{"2024-03-01", 3}, {"2023-11-15", 1}}
+	if !reflect.DeepEqual(cohorts, want) {
+		t.Errorf("cohorts = %v, want %v", cohorts, want)
+	}
+}
+
+func TestGetEmployeeCohortsEmpty(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		return fakeResult{columns: []string{"hire_date", "count"}}
+	})
+
+	w := httptest.NewRecorder()
+	GetEmployeeCohorts(w, httptest.NewRequest(http.MethodGet, "/api/employees/cohorts", nil))
+	if got := w.Body.String(); got != "[]\n" {
+		t.Errorf("body = %q, want an empty array", got)
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// dbErrorMessage is the client-facing response for a database error, with a stable
+// machine-readable code and the message in each supported language
+type dbErrorMessage struct {
+	status int
+	code   string
+	en     string
+	th     string
+}
+
+// dbErrorsByConstraint maps named constraints and indexes to specific messages
+var dbErrorsByConstraint = map[string]dbErrorMessage{
+	"ux_employee_email_lower": {
+		http.StatusConflict, "EMPLOYEE_EMAIL_TAKEN",
+		"An employee with this email already exists",
+		"มีพนักงานที่ใช้อีเมลนี้อยู่แล้ว",
+	},
+	"ux_employee_email_primary": {
+		http.StatusConflict, "PRIMARY_EMAIL_CONFLICT",
+		"The employee already has a primary email of this type",
+		"พนักงานมีอีเมลหลักประเภทนี้อยู่แล้ว",
+	},
+	"m_employee_email_email_type_check": {
+		http.StatusBadRequest, "INVALID_EMAIL_TYPE",
+		"Email type must be company, personal or other",
+		"ประเภทอีเมลต้องเป็น company, personal หรือ other",
+	},
+	"m_employee_email_employee_id_fkey": {
+		http.StatusNotFound, "EMPLOYEE_NOT_FOUND",
+		"Employee not found",
+		"ไม่พบพนักงาน",
+	},
+	"m_employee_document_employee_id_fkey": {
+		http.StatusNotFound, "EMPLOYEE_NOT_FOUND",
+		"Employee not found",
+		"ไม่พบพนักงาน",
+	},
+}
+
+// dbErrorFields names the request field a constraint guards, reported alongside the error
+// so clients can point at the offending input
+var dbErrorFields = map[string]string{
+	"ux_employee_email_lower":           "email",
+	"ux_employee_email_primary":         "is_primary",
+	"m_employee_email_email_type_check": "type",
+}
+
+// queryCanceled is the Postgres code for a statement cancelled by its context or statement_timeout
+const queryCanceled pq.ErrorCode = "57014"
+
+// dbErrorsByCode maps Postgres error codes to generic messages for constraints without a specific entry
+var dbErrorsByCode = map[pq.ErrorCode]dbErrorMessage{
+	queryCanceled: {
+		http.StatusServiceUnavailable, "DATABASE_TIMEOUT",
+		"The database took too long to respond, please retry shortly",
+		"ฐานข้อมูลตอบสนองช้าเกินไป กรุณาลองใหม่อีกครั้ง",
+	},
+	"23505": {
+		http.StatusConflict, "DUPLICATE_VALUE",
+		"A record with the same value already exists",
+		"มีข้อมูลนี้อยู่แล้ว",
+	},
+	"23503": {
+		http.StatusConflict, "REFERENCE_VIOLATION",
+		"The record refers to, or is referred to by, a record that does not allow this change",
+		"ไม่สามารถดำเนินการได้เนื่องจากข้อมูลอ้างอิงไม่ถูกต้องหรือยังถูกใช้งานอยู่",
+	},
+	"23502": {
+		http.StatusBadRequest, "REQUIRED_VALUE_MISSING",
+		"A required value is missing",
+		"ขาดข้อมูลที่จำเป็น",
+	},
+	"23514": {
+		http.StatusBadRequest, "VALUE_NOT_ALLOWED",
+		"A value is outside the allowed range",
+		"มีค่าที่ไม่อยู่ในช่วงที่อนุญาต",
+	},
+	"22001": {
+		http.StatusBadRequest, "VALUE_TOO_LONG",
+		"A value is longer than allowed",
+		"มีข้อมูลที่ยาวเกินกำหนด",
+	},
+	"22007": {
+		http.StatusBadRequest, "INVALID_DATE",
+		"A date is not in a valid format",
+		"รูปแบบวันที่ไม่ถูกต้อง",
+	},
+	"22008": {
+		http.StatusBadRequest, "INVALID_DATE",
+		"A date is out of range",
+		"วันที่อยู่นอกช่วงที่ถูกต้อง",
+	},
+	"22P02": {
+		http.StatusBadRequest, "INVALID_VALUE_FORMAT",
+		"A value is not in a valid format",
+		"รูปแบบข้อมูลไม่ถูกต้อง",
+	},
+}
+
+// lookupDBError returns the client-facing message for a Postgres error, if it has one
+func lookupDBError(err error) (dbErrorMessage, bool) {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return dbErrorsByCode[queryCanceled], true
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return dbErrorMessage{}, false
+	}
+	if message, ok := dbErrorsByConstraint[pqErr.Constraint]; ok {
+		return message, true
+	}
+	message, ok := dbErrorsByCode[pqErr.Code]
+	return message, ok
+}
+
+// prefersThai reports whether the Accept-Language header ranks Thai first
+func prefersThai(r *http.Request) bool {
+	language := strings.TrimSpace(strings.Split(r.Header.Get("Accept-Language"), ",")[0])
+	return strings.HasPrefix(strings.ToLower(language), "th")
+}
+
+// writeDBError responds to a failed database call. Known constraint and data errors get
+// their mapped status with a stable code and a localized message, so Postgres wording
+// and schema names never reach the client. Anything else is an internal error.
+func writeDBError(w http.ResponseWriter, r *http.Request, err error, context string) {
+	message, ok := lookupDBError(err)
+	if !ok {
+		writeInternalError(w, context, err)
+		return
+	}
+
+	text := message.en
+	if prefersThai(r) {
+		text = message.th
+		w.Header().Set("Content-Language", "th")
+	} else {
+		w.Header().Set("Content-Language", "en")
+	}
+
+	detail := ErrorDetail{Code: message.code, Message: text}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		detail.Field = dbErrorFields[pqErr.Constraint]
+	}
+
+	writeError(w, message.status, detail)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestLookupDBError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantOK   bool
+		wantCode string
+		status   int
+	}{
+		{"unique on email", &pq.Error{Code: "23505", Constraint: "ux_employee_email_lower"}, true, "EMPLOYEE_EMAIL_TAKEN", http.StatusConflict},
+		{"unique on other constraint", &pq.Error{Code: "23505", Constraint: "ux_something_else"}, true, "DUPLICATE_VALUE", http.StatusConflict},
+		{"foreign key to employee", &pq.Error{Code: "23503", Constraint: "m_employee_email_employee_id_fkey"}, true, "EMPLOYEE_NOT_FOUND", http.StatusNotFound},
+		{"foreign key elsewhere", &pq.Error{Code: "23503", Constraint: "some_other_fkey"}, true, "REFERENCE_VIOLATION", http.StatusConflict},
+		{"wrapped", fmt.Errorf("inserting employee: %w", &pq.Error{Code: "22001"}), true, "VALUE_TOO_LONG", http.StatusBadRequest},
+		{"statement cancelled", &pq.Error{Code: "57014"}, true, "DATABASE_TIMEOUT", http.StatusServiceUnavailable},
+		{"context deadline", context.DeadlineExceeded, true, "DATABASE_TIMEOUT", http.StatusServiceUnavailable},
+		{"unmapped code", &pq.Error{Code: "42P01"}, false, "", 0},
+		{"not a postgres error", errors.New("connection reset"), false, "", 0},
+	}
+	for _, tt := range tests {
+		message, ok := lookupDBError(tt.err)
+		if ok != tt.wantOK || message.code != tt.wantCode || message.status != tt.status {
+			t.Errorf("%s: got %d %s (%t), want %d %s (%t)", tt.name, message.status, message.code, ok, tt.status, tt.wantCode, tt.wantOK)
+		}
+	}
+}
+
+func TestWriteDBError(t *testing.T) {
+	uniqueEmail := &pq.Error{Code: "23505", Constraint: "ux_employee_email_lower", Message: `duplicate key value violates unique constraint "ux_employee_email_lower"`}
+
+	tests := []struct {
+		name         string
+		language     string
+		wantLanguage string
+		wantMessage  string
+	}{
+		{"english", "", "en", "An employee with this email already exists"},
+		{"thai", "th-TH,th;q=0.9,en;q=0.8", "th", "มีพนักงานที่ใช้อีเมลนี้อยู่แล้ว"},
+		{"thai ranked second", "en-US,th;q=0.5", "en", "An employee with this email already exists"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/api/employee", nil)
+		if tt.language != "" {
+			r.Header.Set("Accept-Language", tt.language)
+		}
+		w := httptest.NewRecorder()
+		writeDBError(w, r, uniqueEmail, "Error creating employee")
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("%s: status = %d, want 409", tt.name, w.Code)
+		}
+		if got := w.Header().Get("Content-Language"); got != tt.wantLanguage {
+			t.Errorf("%s: Content-Language = %q, want %q", tt.name, got, tt.wantLanguage)
+		}
+		detail := decodeError(t, w)
+		if detail.Code != "EMPLOYEE_EMAIL_TAKEN" || detail.Message != tt.wantMessage || detail.Field != "email" {
+			t.Errorf("%s: error = %+v", tt.name, detail)
+		}
+	}
+}
+
+func TestWriteDBErrorForeignKey(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := &pq.Error{Code: "23503", Constraint: "m_employee_document_employee_id_fkey"}
+	writeDBError(w, httptest.NewRequest(http.MethodPost, "/api/employee/x/documents", nil), err, "Error uploading document")
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+	if detail := decodeError(t, w); detail.Code != "EMPLOYEE_NOT_FOUND" || detail.Field != "" {
+		t.Errorf("error = %+v, want EMPLOYEE_NOT_FOUND without a field", detail)
+	}
+}
+
+func TestWriteDBErrorUnknownIsInternal(t *testing.T) {
+	MaskInternalErrors = true
+	t.Cleanup(func() { MaskInternalErrors = false })
+
+	w := httptest.NewRecorder()
+	err := &pq.Error{Code: "42P01", Message: `relation "m_employee" does not exist`}
+	writeDBError(w, httptest.NewRequest(http.MethodGet, "/api/employee/x", nil), err, "Error retrieving employee")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+	if detail := decodeError(t, w); detail.Code != "INTERNAL_ERROR" || detail.Message != "Error retrieving employee" {
+		t.Errorf("error = %+v, want a masked INTERNAL_ERROR", detail)
+	}
+}
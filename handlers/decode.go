@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// JSONBodyMaxBytes is the largest JSON request body accepted
+var JSONBodyMaxBytes int64 = 1 << 20
+
+// decodeJSONBody decodes the request body into dst. On failure it answers with 413
+// when the body is larger than JSONBodyMaxBytes, or with a 400 describing what was
+// wrong with it, and returns false.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, JSONBodyMaxBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE", "Request body exceeds the "+strconv.FormatInt(JSONBodyMaxBytes, 10)+" byte limit")
+			return false
+		}
+		writeJSONError(w, http.StatusBadRequest, "INVALID_BODY", "Error reading request body")
+		return false
+	}
+
+	if message := describeJSONError(body, json.Unmarshal(body, dst)); message != "" {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_BODY", message)
+		return false
+	}
+	return true
+}
+
+// describeJSONError explains why body could not be decoded, or returns "" if err is nil
+func describeJSONError(body []byte, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case len(bytes.TrimSpace(body)) == 0:
+		return "Request body is empty"
+	case errors.As(err, &syntaxErr):
+		line, column := lineAndColumn(body, syntaxErr.Offset)
+		return fmt.Sprintf("Malformed JSON at line %d, column %d (byte offset %d): %s",
+			line, column, syntaxErr.Offset, syntaxErr.Error())
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return fmt.Sprintf("Request body must be a JSON %s, got %s", jsonTypeName(typeErr.Type), typeErr.Value)
+		}
+		return fmt.Sprintf("Field %q must be of type %s, got %s", typeErr.Field, jsonTypeName(typeErr.Type), typeErr.Value)
+	default:
+		return "Invalid request body: " + err.Error()
+	}
+}
+
+// lineAndColumn converts the offset of a json.SyntaxError, which counts the offending
+// byte, into the 1-based line and column of that byte
+func lineAndColumn(body []byte, offset int64) (int, int) {
+	if offset > int64(len(body)) {
+		offset = int64(len(body))
+	}
+	if offset > 0 {
+		offset--
+	}
+	before := body[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// jsonTypeName describes a Go type using the JSON type a client would need to send
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSONBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{"string into int", `{"first_name":"Somchai","gender":"male"}`, http.StatusBadRequest, `Field "gender" must be of type integer, got string`},
+		{"not an object", `[1, 2]`, http.StatusBadRequest, "Request body must be a JSON object, got array"},
+		{"malformed", "{\n  \"first_name\": \"Somchai\",\n  \"last_name\" \"Jaidee\"\n}", http.StatusBadRequest, "Malformed JSON at line 3, column 15 (byte offset 44)"},
+		{"empty", "  ", http.StatusBadRequest, "Request body is empty"},
+		{"too large", `{"nickname":"` + strings.Repeat("a", 128) + `"}`, http.StatusRequestEntityTooLarge, "Request body exceeds the 100 byte limit"},
+	}
+
+	previous := JSONBodyMaxBytes
+	JSONBodyMaxBytes = 100
+	t.Cleanup(func() { JSONBodyMaxBytes = previous })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			var employee Employee
+			if decodeJSONBody(w, httptest.NewRequest(http.MethodPost, "/api/employee", strings.NewReader(tt.body)), &employee) {
+				t.Fatal("decodeJSONBody succeeded, want an error")
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if detail := decodeError(t, w); !strings.HasPrefix(detail.Message, tt.wantMessage) {
+				t.Errorf("message = %q, want prefix %q", detail.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBodyValid(t *testing.T) {
+	w := httptest.NewRecorder()
+	var employee Employee
+	r := httptest.NewRequest(http.MethodPost, "/api/employee", strings.NewReader(`{"first_name":"Somchai","gender":1}`))
+	if !decodeJSONBody(w, r, &employee) {
+		t.Fatalf("decodeJSONBody failed: %s", w.Body.String())
+	}
+	if employee.FirstName != "Somchai" || employee.Gender != 1 {
+		t.Errorf("decoded %+v", employee)
+	}
+}
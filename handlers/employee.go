@@ -1,29 +1,35 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
-	"encoding/json"
+	"encoding/xml"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type Employee struct {
-	ID             string `json:"id"`
-	EmployeeCode   string `json:"employee_code"`
-	PrefixName     string `json:"prefix_name"`
-	FirstName      string `json:"first_name"`
-	LastName       string `json:"last_name"`
-	Nickname       string `json:"nickname"`
-	Email          string `json:"email"`
-	PhoneNumber    string `json:"phone_number"`
-	Gender         int    `json:"gender"`
-	BirthDate      string `json:"birth_date"`
-	HireDate       string `json:"hire_date"`
-	Department     string `json:"department"`
-	Position       string `json:"position"`
-	EmploymentType int    `json:"employment_type"`
-	IsActive       bool   `json:"is_active"`
-	CreatedAt      string `json:"created_at"`
-	UpdatedAt      string `json:"updated_at"`
+	XMLName        xml.Name `json:"-" xml:"employee" swaggerignore:"true"`
+	ID             string   `json:"id" xml:"id"`
+	EmployeeCode   string   `json:"employee_code" xml:"employee_code"`
+	PrefixName     string   `json:"prefix_name" xml:"prefix_name"`
+	FirstName      string   `json:"first_name" xml:"first_name"`
+	LastName       string   `json:"last_name" xml:"last_name"`
+	Nickname       string   `json:"nickname" xml:"nickname"`
+	Email          string   `json:"email" xml:"email"`
+	PhoneNumber    string   `json:"phone_number" xml:"phone_number"`
+	Gender         int      `json:"gender" xml:"gender"`
+	BirthDate      string   `json:"birth_date" xml:"birth_date"`
+	HireDate       string   `json:"hire_date" xml:"hire_date"`
+	Department     string   `json:"department" xml:"department"`
+	Position       string   `json:"position" xml:"position"`
+	EmploymentType int      `json:"employment_type" xml:"employment_type"`
+	IsActive       bool     `json:"is_active" xml:"is_active"`
+	CreatedAt      string   `json:"created_at" xml:"created_at"`
+	UpdatedAt      string   `json:"updated_at" xml:"updated_at"`
+	CreatedBy      string   `json:"created_by" xml:"created_by"`
+	UpdatedBy      string   `json:"updated_by" xml:"updated_by"`
 }
 
 var DB *sql.DB
@@ -36,43 +42,64 @@ var DB *sql.DB
 // @Produce json
 // @Param employee body Employee true "Employee object that needs to be created"
 // @Success 201 {object} Employee
-// @Failure 400 {string} string "Invalid request body or missing required fields"
-// @Failure 405 {string} string "Method not allowed"
-// @Failure 500 {string} string "Error creating employee"
+// @Failure 400 {object} ErrorResponse "Malformed JSON, mistyped field or invalid fields"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 409 {object} ErrorResponse "An employee with this email already exists"
+// @Failure 413 {object} ErrorResponse "Request body too large"
+// @Failure 500 {object} ErrorResponse "Error creating employee"
+// @Security BearerAuth
 // @Router /employee [post]
 func CreateEmployee(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	userID, ok := requireUser(w, r)
+	if !ok {
 		return
 	}
 
 	var employee Employee
-	err := json.NewDecoder(r.Body).Decode(&employee)
-	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSONBody(w, r, &employee) {
 		return
 	}
 
-	// Validate required fields
-	if employee.PrefixName == "" || employee.FirstName == "" || employee.LastName == "" {
-		http.Error(w, "prefix_name, first_name and last_name are required", http.StatusBadRequest)
+	// Audit fields always come from the authenticated user, never from the body
+	employee.CreatedBy = userID
+	employee.UpdatedBy = userID
+
+	// Emails are unique regardless of case, so store them in one canonical form
+	employee.Email = normalizeEmail(employee.Email)
+
+	if errs := employee.validate(); len(errs) > 0 {
+		writeValidationErrors(w, errs)
 		return
 	}
 
-	// Insert employee into database
-	query := `INSERT INTO m_employee (employee_code, prefix_name, first_name, last_name, nickname, email, phone_number, gender, birth_date, hire_date, department, position, employment_type) 
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
+	ctx, cancel := queryContext(r)
+	defer cancel()
 
-	err = DB.QueryRow(query, "", employee.PrefixName, employee.FirstName, employee.LastName, "", "", "", 0, nil, nil, "", "", 0).Scan(&employee.ID)
+	tx, err := DB.BeginTx(ctx, nil)
 	if err != nil {
-		http.Error(w, "Error creating employee: "+err.Error(), http.StatusInternalServerError)
+		writeDBError(w, r, err, "Error creating employee")
+		return
+	}
+	defer tx.Rollback()
+
+	if err := insertEmployee(ctx, tx, &employee); err != nil {
+		writeDBError(w, r, err, "Error creating employee")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		writeDBError(w, r, err, "Error creating employee")
 		return
 	}
 
 	// Return created employee
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(employee)
+	writeJSON(w, http.StatusCreated, employee)
 }
 
 // GetEmployeeByID godoc
@@ -80,42 +107,147 @@ func CreateEmployee(w http.ResponseWriter, r *http.Request) {
 // @Description Get employee details by employee ID
 // @Tags employee
 // @Accept json
-// @Produce json
+// @Produce json,xml
 // @Param id path string true "Employee ID (UUID)"
 // @Success 200 {object} Employee
-// @Failure 400 {string} string "Employee ID is required"
-// @Failure 404 {string} string "Employee not found"
-// @Failure 405 {string} string "Method not allowed"
-// @Failure 500 {string} string "Error retrieving employee"
+// @Failure 400 {object} ErrorResponse "Missing or invalid employee ID, or unknown query parameters"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Employee not found"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 500 {object} ErrorResponse "Error retrieving employee"
+// @Security BearerAuth
 // @Router /employee/{id} [get]
 func GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r) {
 		return
 	}
 
-	// Get employee ID from URL path
-	// Extract ID from path like /api/employee/123
-	path := r.URL.Path
-	employeeID := path[len("/api/employee/"):]
+	// Get employee ID from URL path like /api/employee/123
+	employeeID := employeePathParts(r)[0]
 
-	if employeeID == "" {
-		http.Error(w, "Employee ID is required", http.StatusBadRequest)
+	// Anything else would reach Postgres and fail the uuid cast with a 500
+	if !isUUID(employeeID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee ID")
 		return
 	}
 
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
 	// Query employee from database
-	query := `SELECT id, employee_code, prefix_name, first_name, last_name, nickname, 
-				email, phone_number, gender, birth_date, hire_date, department, 
-				position, employment_type, is_active, created_at, updated_at 
-			  FROM m_employee WHERE id = $1`
+	employee, err := fetchEmployee(ctx, employeeID)
+
+	if err == sql.ErrNoRows {
+		writeJSONError(w, http.StatusNotFound, "EMPLOYEE_NOT_FOUND", "Employee not found")
+		return
+	}
 
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving employee")
+		return
+	}
+
+	// Return employee as JSON, or XML when the client asks for it
+	writeNegotiated(w, r, http.StatusOK, employee)
+}
+
+// insertEmployee stores a validated employee and fills in the ID and the defaults
+// the database assigns, such as is_active and the timestamps. An email is also
+// recorded as the primary company address, so it is listed and kept in sync by
+// the employee emails API.
+func insertEmployee(ctx context.Context, tx *sql.Tx, employee *Employee) error {
+	query := `INSERT INTO m_employee (employee_code, prefix_name, first_name, last_name, nickname, email, phone_number, gender, birth_date, hire_date, department, position, employment_type, created_by, updated_by)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+				RETURNING id, is_active, created_at, updated_at`
+
+	var isActive sql.NullBool
+	var createdAt, updatedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, query,
+		employee.EmployeeCode,
+		employee.PrefixName,
+		employee.FirstName,
+		employee.LastName,
+		employee.Nickname,
+		nullIfEmpty(employee.Email),
+		employee.PhoneNumber,
+		employee.Gender,
+		nullIfEmpty(employee.BirthDate),
+		nullIfEmpty(employee.HireDate),
+		employee.Department,
+		employee.Position,
+		employee.EmploymentType,
+		nullIfEmpty(employee.CreatedBy),
+		nullIfEmpty(employee.UpdatedBy),
+	).Scan(&employee.ID, &isActive, &createdAt, &updatedAt)
+	if err != nil {
+		return err
+	}
+
+	employee.IsActive = isActive.Bool
+	if createdAt.Valid {
+		employee.CreatedAt = formatTimestamp(createdAt.Time)
+	}
+	if updatedAt.Valid {
+		employee.UpdatedAt = formatTimestamp(updatedAt.Time)
+	}
+
+	if employee.Email == "" {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO m_employee_email (employee_id, email, email_type, is_primary)
+		VALUES ($1, $2, $3, TRUE)`, employee.ID, employee.Email, EmailTypeCompany)
+	return err
+}
+
+// formatTimestamp renders a timestamp as RFC3339 in UTC, the format used by every response
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// normalizeEmail trims and lower-cases an email address
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// nullIfEmpty maps an empty string to NULL for optional columns
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// employeeColumns lists the m_employee columns in the order scanEmployee expects them
+const employeeColumns = `id, employee_code, prefix_name, first_name, last_name, nickname,
+	email, phone_number, gender, birth_date, hire_date, department,
+	position, employment_type, is_active, created_at, updated_at,
+	created_by, updated_by`
+
+// fetchEmployee returns the employee with the given ID, or sql.ErrNoRows if there is none
+func fetchEmployee(ctx context.Context, employeeID string) (Employee, error) {
+	query := `SELECT ` + employeeColumns + ` FROM m_employee WHERE id = $1`
+	return scanEmployee(DB.QueryRowContext(ctx, query, employeeID))
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEmployee scans a row selected with employeeColumns into an Employee
+func scanEmployee(row rowScanner) (Employee, error) {
 	var employee Employee
 	var birthDate, hireDate, createdAt, updatedAt sql.NullTime
 	var employeeCode, nickname, email, phoneNumber, department, position sql.NullString
+	var createdBy, updatedBy sql.NullString
 	var gender, employmentType sql.NullInt32
 
-	err := DB.QueryRow(query, employeeID).Scan(
+	err := row.Scan(
 		&employee.ID,
 		&employeeCode,
 		&employee.PrefixName,
@@ -133,16 +265,11 @@ func GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
 		&employee.IsActive,
 		&createdAt,
 		&updatedAt,
+		&createdBy,
+		&updatedBy,
 	)
-
-	if err == sql.ErrNoRows {
-		http.Error(w, "Employee not found", http.StatusNotFound)
-		return
-	}
-
 	if err != nil {
-		http.Error(w, "Error retrieving employee: "+err.Error(), http.StatusInternalServerError)
-		return
+		return employee, err
 	}
 
 	// Handle nullable fields
@@ -177,14 +304,17 @@ func GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
 		employee.EmploymentType = int(employmentType.Int32)
 	}
 	if createdAt.Valid {
-		employee.CreatedAt = createdAt.Time.Format("2006-01-02 15:04:05")
+		employee.CreatedAt = formatTimestamp(createdAt.Time)
 	}
 	if updatedAt.Valid {
-		employee.UpdatedAt = updatedAt.Time.Format("2006-01-02 15:04:05")
+		employee.UpdatedAt = formatTimestamp(updatedAt.Time)
+	}
+	if createdBy.Valid {
+		employee.CreatedBy = createdBy.String
+	}
+	if updatedBy.Valid {
+		employee.UpdatedBy = updatedBy.String
 	}
 
-	// Return employee
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(employee)
+	return employee, nil
 }
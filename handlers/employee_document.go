@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DocumentMaxBytes is the largest document, in bytes, that may be uploaded
+var DocumentMaxBytes int64 = 10 << 20
+
+// DocumentAllowedTypes lists the content types documents may have, detected from the file contents
+var DocumentAllowedTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+type EmployeeDocument struct {
+	ID          string `json:"id"`
+	EmployeeID  string `json:"employee_id"`
+	Type        string `json:"type"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	UploadedBy  string `json:"uploaded_by"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// employeeDocumentColumns lists the m_employee_document metadata columns in the order
+// scanEmployeeDocument expects them. The file content is only selected for downloads.
+const employeeDocumentColumns = `id, employee_id, document_type, file_name, content_type,
+	size_bytes, uploaded_by, created_at`
+
+// scanEmployeeDocument scans a row selected with employeeDocumentColumns into an EmployeeDocument
+func scanEmployeeDocument(row rowScanner) (EmployeeDocument, error) {
+	var document EmployeeDocument
+	var uploadedBy sql.NullString
+	var createdAt sql.NullTime
+
+	err := row.Scan(
+		&document.ID,
+		&document.EmployeeID,
+		&document.Type,
+		&document.FileName,
+		&document.ContentType,
+		&document.Size,
+		&uploadedBy,
+		&createdAt,
+	)
+	if err != nil {
+		return document, err
+	}
+
+	if uploadedBy.Valid {
+		document.UploadedBy = uploadedBy.String
+	}
+	if createdAt.Valid {
+		document.CreatedAt = formatTimestamp(createdAt.Time)
+	}
+
+	return document, nil
+}
+
+// fetchEmployeeDocuments returns the metadata of an employee's documents that have not been deleted, newest first
+func fetchEmployeeDocuments(ctx context.Context, employeeID string) ([]EmployeeDocument, error) {
+	query := `SELECT ` + employeeDocumentColumns + ` FROM m_employee_document
+			  WHERE employee_id = $1 AND deleted_at IS NULL
+			  ORDER BY created_at DESC, id`
+
+	rows, err := DB.QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []EmployeeDocument{}
+	for rows.Next() {
+		document, err := scanEmployeeDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, rows.Err()
+}
+
+// documentTypeAllowed reports whether contentType is in DocumentAllowedTypes
+func documentTypeAllowed(contentType string) bool {
+	for _, allowed := range DocumentAllowedTypes {
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadEmployeeDocument godoc
+// @Summary Upload an employee document
+// @Description Attach a document such as a contract or ID card to an employee. The content type is detected from the file and must be one of the allowed types.
+// @Tags employee
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Employee ID (UUID)"
+// @Param file formData file true "Document file"
+// @Param type formData string true "Document type, e.g. contract or id_card"
+// @Param uploaded_by formData string false "Who uploaded the document, only used when authentication is disabled"
+// @Success 201 {object} EmployeeDocument
+// @Failure 400 {object} ErrorResponse "Invalid employee ID, form, document type or missing file"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Employee not found"
+// @Failure 413 {object} ErrorResponse "Document too large"
+// @Failure 415 {object} ErrorResponse "Document content type not allowed"
+// @Failure 500 {object} ErrorResponse "Error uploading document"
+// @Security BearerAuth
+// @Router /employee/{id}/documents [post]
+func UploadEmployeeDocument(w http.ResponseWriter, r *http.Request) {
+	employeeID := employeePathParts(r)[0]
+	if !isUUID(employeeID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	// Leave headroom for the multipart envelope and the other form fields
+	r.Body = http.MaxBytesReader(w, r.Body, DocumentMaxBytes+1<<20)
+	if err := r.ParseMultipartForm(DocumentMaxBytes); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "DOCUMENT_TOO_LARGE", "Document exceeds the "+strconv.FormatInt(DocumentMaxBytes, 10)+" byte limit")
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, "INVALID_BODY", "Invalid multipart form: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	documentType := strings.TrimSpace(r.FormValue("type"))
+	if documentType == "" || len(documentType) > 50 {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_FIELD", "type is required and must be at most 50 characters")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_FIELD", "file is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > DocumentMaxBytes {
+		writeJSONError(w, http.StatusRequestEntityTooLarge, "DOCUMENT_TOO_LARGE", "Document exceeds the "+strconv.FormatInt(DocumentMaxBytes, 10)+" byte limit")
+		return
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_BODY", "Error reading uploaded file")
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	if !documentTypeAllowed(contentType) {
+		writeJSONError(w, http.StatusUnsupportedMediaType, "UNSUPPORTED_DOCUMENT_TYPE", "Document content type "+contentType+" is not allowed")
+		return
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	exists, err := employeeExists(ctx, employeeID)
+	if err != nil {
+		writeDBError(w, r, err, "Error uploading document")
+		return
+	}
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "EMPLOYEE_NOT_FOUND", "Employee not found")
+		return
+	}
+
+	document := EmployeeDocument{
+		EmployeeID:  employeeID,
+		Type:        documentType,
+		FileName:    filepath.Base(header.Filename),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		UploadedBy:  userID,
+	}
+	// The form field is only trusted when there is no authenticated user to record
+	if !AuthEnabled {
+		document.UploadedBy = strings.TrimSpace(r.FormValue("uploaded_by"))
+	}
+
+	query := `INSERT INTO m_employee_document (employee_id, document_type, file_name, content_type, size_bytes, content, uploaded_by)
+			  VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')) RETURNING id, created_at`
+
+	var createdAt sql.NullTime
+	err = DB.QueryRowContext(ctx, query, employeeID, document.Type, document.FileName, document.ContentType,
+		document.Size, content, document.UploadedBy).Scan(&document.ID, &createdAt)
+	if err != nil {
+		writeDBError(w, r, err, "Error uploading document")
+		return
+	}
+
+	if createdAt.Valid {
+		document.CreatedAt = formatTimestamp(createdAt.Time)
+	}
+
+	writeJSON(w, http.StatusCreated, document)
+}
+
+// ListEmployeeDocuments godoc
+// @Summary List an employee's documents
+// @Description Get the metadata of every document attached to an employee that has not been deleted, newest first
+// @Tags employee
+// @Produce json
+// @Param id path string true "Employee ID (UUID)"
+// @Success 200 {array} EmployeeDocument
+// @Failure 400 {object} ErrorResponse "Invalid employee ID"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Employee not found"
+// @Failure 500 {object} ErrorResponse "Error retrieving documents"
+// @Security BearerAuth
+// @Router /employee/{id}/documents [get]
+func ListEmployeeDocuments(w http.ResponseWriter, r *http.Request) {
+	employeeID := employeePathParts(r)[0]
+	if !isUUID(employeeID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	exists, err := employeeExists(ctx, employeeID)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving documents")
+		return
+	}
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "EMPLOYEE_NOT_FOUND", "Employee not found")
+		return
+	}
+
+	documents, err := fetchEmployeeDocuments(ctx, employeeID)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving documents")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, documents)
+}
+
+// DownloadEmployeeDocument godoc
+// @Summary Download an employee document
+// @Description Download the file content of a document attached to an employee
+// @Tags employee
+// @Produce application/octet-stream
+// @Param id path string true "Employee ID (UUID)"
+// @Param documentId path string true "Document ID (UUID)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse "Invalid employee or document ID"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Document not found"
+// @Failure 500 {object} ErrorResponse "Error retrieving document"
+// @Security BearerAuth
+// @Router /employee/{id}/documents/{documentId} [get]
+func DownloadEmployeeDocument(w http.ResponseWriter, r *http.Request) {
+	parts := employeePathParts(r)
+	employeeID, documentID := parts[0], parts[2]
+	if !isUUID(employeeID) || !isUUID(documentID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee or document ID")
+		return
+	}
+
+	query := `SELECT file_name, content_type, content FROM m_employee_document
+			  WHERE id = $1 AND employee_id = $2 AND deleted_at IS NULL`
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	var fileName, contentType string
+	var content []byte
+	err := DB.QueryRowContext(ctx, query, documentID, employeeID).Scan(&fileName, &contentType, &content)
+	if err == sql.ErrNoRows {
+		writeJSONError(w, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Document not found")
+		return
+	}
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving document")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// DeleteEmployeeDocument godoc
+// @Summary Delete an employee document
+// @Description Soft-delete a document attached to an employee so it no longer appears in listings or downloads
+// @Tags employee
+// @Param id path string true "Employee ID (UUID)"
+// @Param documentId path string true "Document ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorResponse "Invalid employee or document ID"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Document not found"
+// @Failure 500 {object} ErrorResponse "Error deleting document"
+// @Security BearerAuth
+// @Router /employee/{id}/documents/{documentId} [delete]
+func DeleteEmployeeDocument(w http.ResponseWriter, r *http.Request) {
+	parts := employeePathParts(r)
+	employeeID, documentID := parts[0], parts[2]
+	if !isUUID(employeeID) || !isUUID(documentID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee or document ID")
+		return
+	}
+
+	if _, ok := requireUser(w, r); !ok {
+		return
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	result, err := DB.ExecContext(ctx, `UPDATE m_employee_document SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND employee_id = $2 AND deleted_at IS NULL`, documentID, employeeID)
+	if err != nil {
+		writeDBError(w, r, err, "Error deleting document")
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		writeDBError(w, r, err, "Error deleting document")
+		return
+	}
+	if affected == 0 {
+		writeJSONError(w, http.StatusNotFound, "DOCUMENT_NOT_FOUND", "Document not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testEmployeeID = "4f8c2a9e-1b7d-4c3a-9e5f-6a2b8d0c1e3f"
+	testDocumentID = "9a1b2c3d-4e5f-4a6b-8c7d-0e1f2a3b4c5d"
+)
+
+// pngHeader is enough of a PNG file for http.DetectContentType to recognise it
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// fakeDocumentStore answers the document queries for a single employee and document
+type fakeDocumentStore struct {
+	stored  bool
+	deleted bool
+	content []byte
+}
+
+func (s *fakeDocumentStore) query(query string, args []driver.Value) fakeResult {
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	switch {
+	case strings.Contains(query, "SELECT EXISTS"):
+		return fakeResult{columns: []string{"exists"}, rows: [][]driver.Value{{args[0] == testEmployeeID}}}
+	case strings.HasPrefix(strings.TrimSpace(query), "INSERT INTO m_employee_document"):
+		s.stored, s.content = true, args[5].([]byte)
+		return fakeResult{columns: []string{"id", "created_at"}, rows: [][]driver.Value{{testDocumentID, created}}}
+	case strings.HasPrefix(strings.TrimSpace(query), "UPDATE m_employee_document"):
+		if !s.stored || s.deleted {
+			return fakeResult{}
+		}
+		s.deleted = true
+		return fakeResult{rows: [][]driver.Value{{}}}
+	case strings.Contains(query, "SELECT file_name"):
+		if !s.stored || s.deleted {
+			return fakeResult{columns: []string{"file_name", "content_type", "content"}}
+		}
+		return fakeResult{columns: []string{"file_name", "content_type", "content"}, rows: [][]driver.Value{{"id card.png", "image/png", s.content}}}
+	case strings.Contains(query, "FROM m_employee_document"):
+		result := fakeResult{columns: []string{"id", "employee_id", "document_type", "file_name", "content_type", "size_bytes", "uploaded_by", "created_at"}}
+		if s.stored && !s.deleted {
+			result.rows = [][]driver.Value{{testDocumentID, testEmployeeID, "id_card", "id card.png", "image/png", int64(len(s.content)), nil, created}}
+		}
+		return result
+	}
+	return fakeResult{}
+}
+
+// documentUpload builds a multipart upload of content as an employee document
+func documentUpload(t *testing.T, employeeID string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("type", "id_card")
+	part, err := form.CreateFormFile("file", "id card.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	form.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/api/employee/"+employeeID+"/documents", &body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	return r
+}
+
+func TestEmployeeDocumentLifecycle(t *testing.T) {
+	store := &fakeDocumentStore{}
+	useFakeDB(t, store.query)
+	documentPath := "/api/employee/" + testEmployeeID + "/documents/" + testDocumentID
+
+	w := httptest.NewRecorder()
+	EmployeeRouter(w, documentUpload(t, testEmployeeID, pngHeader))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("upload: status = %d, body %s", w.Code, w.Body.String())
+	}
+	var uploaded EmployeeDocument
+	json.Unmarshal(w.Body.Bytes(), &uploaded)
+	if uploaded.ID != testDocumentID || uploaded.ContentType != "image/png" || uploaded.Size != int64(len(pngHeader)) || uploaded.CreatedAt != "2024-03-01T09:00:00Z" {
+		t.Errorf("upload: document = %+v", uploaded)
+	}
+
+	w = httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodGet, "/api/employee/"+testEmployeeID+"/documents", nil))
+	var listed []EmployeeDocument
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if w.Code != http.StatusOK || len(listed) != 1 || listed[0].FileName != "id card.png" {
+		t.Errorf("list: status = %d, documents = %+v", w.Code, listed)
+	}
+
+	w = httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodGet, documentPath, nil))
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), pngHeader) {
+		t.Errorf("download: status = %d, body %q", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("download: Content-Type = %q, want image/png", got)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="id card.png"` {
+		t.Errorf("download: Content-Disposition = %q", got)
+	}
+
+	w = httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodDelete, documentPath, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("delete: status = %d, want 204", w.Code)
+	}
+
+	// A deleted document is gone from downloads, listings and further deletes
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		w = httptest.NewRecorder()
+		EmployeeRouter(w, httptest.NewRequest(method, documentPath, nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s after delete: status = %d, want 404", method, w.Code)
+		} else if detail := decodeError(t, w); detail.Code != "DOCUMENT_NOT_FOUND" {
+			t.Errorf("%s after delete: code = %q, want DOCUMENT_NOT_FOUND", method, detail.Code)
+		}
+	}
+	w = httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodGet, "/api/employee/"+testEmployeeID+"/documents", nil))
+	if strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("list after delete: body %s, want []", w.Body.String())
+	}
+}
+
+func TestUploadEmployeeDocumentRejected(t *testing.T) {
+	previous := DocumentMaxBytes
+	DocumentMaxBytes = 64
+	t.Cleanup(func() { DocumentMaxBytes = previous })
+
+	store := &fakeDocumentStore{}
+	useFakeDB(t, store.query)
+
+	tests := []struct {
+		name       string
+		employeeID string
+		content    []byte
+		wantStatus int
+		wantCode   string
+	}{
+		{"disallowed type", testEmployeeID, []byte("#!/bin/sh\necho hello\n"), http.StatusUnsupportedMediaType, "UNSUPPORTED_DOCUMENT_TYPE"},
+		{"too large", testEmployeeID, append(pngHeader, make([]byte, 100)...), http.StatusRequestEntityTooLarge, "DOCUMENT_TOO_LARGE"},
+		{"unknown employee", "00000000-0000-4000-8000-000000000000", pngHeader, http.StatusNotFound, "EMPLOYEE_NOT_FOUND"},
+		{"invalid employee id", "42", pngHeader, http.StatusBadRequest, "INVALID_ID"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		EmployeeRouter(w, documentUpload(t, tt.employeeID, tt.content))
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if detail := decodeError(t, w); detail.Code != tt.wantCode {
+			t.Errorf("%s: code = %q, want %s", tt.name, detail.Code, tt.wantCode)
+		}
+	}
+	if store.stored {
+		t.Error("a rejected upload was stored")
+	}
+}
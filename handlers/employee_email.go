@@ -0,0 +1,384 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/mail"
+)
+
+// Email types an employee address can be recorded as
+const (
+	EmailTypeCompany  = "company"
+	EmailTypePersonal = "personal"
+	EmailTypeOther    = "other"
+)
+
+type EmployeeEmail struct {
+	ID         string `json:"id"`
+	EmployeeID string `json:"employee_id"`
+	Email      string `json:"email"`
+	Type       string `json:"type"`
+	IsPrimary  bool   `json:"is_primary"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// employeeEmailColumns lists the m_employee_email columns in the order scanEmployeeEmail expects them
+const employeeEmailColumns = `id, employee_id, email, email_type, is_primary, created_at`
+
+// scanEmployeeEmail scans a row selected with employeeEmailColumns into an EmployeeEmail
+func scanEmployeeEmail(row rowScanner) (EmployeeEmail, error) {
+	var email EmployeeEmail
+	var createdAt sql.NullTime
+
+	err := row.Scan(&email.ID, &email.EmployeeID, &email.Email, &email.Type, &email.IsPrimary, &createdAt)
+	if err != nil {
+		return email, err
+	}
+
+	if createdAt.Valid {
+		email.CreatedAt = formatTimestamp(createdAt.Time)
+	}
+
+	return email, nil
+}
+
+// employeeExists reports whether an employee with the given ID exists
+func employeeExists(ctx context.Context, employeeID string) (bool, error) {
+	var exists bool
+	err := DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM m_employee WHERE id = $1)`, employeeID).Scan(&exists)
+	return exists, err
+}
+
+// fetchEmployeeEmails returns every email recorded for an employee, primary addresses first
+func fetchEmployeeEmails(ctx context.Context, employeeID string) ([]EmployeeEmail, error) {
+	query := `SELECT ` + employeeEmailColumns + ` FROM m_employee_email
+			  WHERE employee_id = $1
+			  ORDER BY email_type, is_primary DESC, created_at`
+
+	rows, err := DB.QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	emails := []EmployeeEmail{}
+	for rows.Next() {
+		email, err := scanEmployeeEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+
+	return emails, rows.Err()
+}
+
+// syncCompanyEmail copies the employee's primary company address onto m_employee.email,
+// attributing the change to userID
+func syncCompanyEmail(ctx context.Context, tx *sql.Tx, employeeID, userID string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE m_employee SET email = (
+			SELECT email FROM m_employee_email
+			WHERE employee_id = $1 AND email_type = 'company' AND is_primary
+		), updated_at = CURRENT_TIMESTAMP, updated_by = NULLIF($2, '')
+		WHERE id = $1`, employeeID, userID)
+	return err
+}
+
+// setPrimaryEmail makes the given address the only primary one of its type
+func setPrimaryEmail(ctx context.Context, tx *sql.Tx, employeeID, emailID, emailType string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE m_employee_email SET is_primary = FALSE
+		WHERE employee_id = $1 AND email_type = $2 AND is_primary AND id <> $3`,
+		employeeID, emailType, emailID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE m_employee_email SET is_primary = TRUE WHERE id = $1`, emailID)
+	return err
+}
+
+// ListEmployeeEmails godoc
+// @Summary List an employee's emails
+// @Description Get every email address recorded for an employee, primary addresses first
+// @Tags employee
+// @Produce json
+// @Param id path string true "Employee ID (UUID)"
+// @Success 200 {array} EmployeeEmail
+// @Failure 400 {object} ErrorResponse "Invalid employee ID"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Employee not found"
+// @Failure 500 {object} ErrorResponse "Error retrieving emails"
+// @Security BearerAuth
+// @Router /employee/{id}/emails [get]
+func ListEmployeeEmails(w http.ResponseWriter, r *http.Request) {
+	employeeID := employeePathParts(r)[0]
+	if !isUUID(employeeID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	exists, err := employeeExists(ctx, employeeID)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving emails")
+		return
+	}
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "EMPLOYEE_NOT_FOUND", "Employee not found")
+		return
+	}
+
+	emails, err := fetchEmployeeEmails(ctx, employeeID)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving emails")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, emails)
+}
+
+// AddEmployeeEmail godoc
+// @Summary Add an email to an employee
+// @Description Record an additional email address for an employee. Marking a company address as primary also updates the employee's main email.
+// @Tags employee
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID (UUID)"
+// @Param email body EmployeeEmail true "Email address with type (company, personal or other) and is_primary"
+// @Success 201 {object} EmployeeEmail
+// @Failure 400 {object} ErrorResponse "Invalid employee ID, request body, email or type"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Employee not found"
+// @Failure 409 {object} ErrorResponse "An employee with this email already exists"
+// @Failure 413 {object} ErrorResponse "Request body too large"
+// @Failure 500 {object} ErrorResponse "Error adding email"
+// @Security BearerAuth
+// @Router /employee/{id}/emails [post]
+func AddEmployeeEmail(w http.ResponseWriter, r *http.Request) {
+	employeeID := employeePathParts(r)[0]
+	if !isUUID(employeeID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var email EmployeeEmail
+	if !decodeJSONBody(w, r, &email) {
+		return
+	}
+
+	email.Email = normalizeEmail(email.Email)
+	if address, err := mail.ParseAddress(email.Email); err != nil || address.Address != email.Email {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_EMAIL", "email must be a valid email address")
+		return
+	}
+
+	switch email.Type {
+	case EmailTypeCompany, EmailTypePersonal, EmailTypeOther:
+	default:
+		writeJSONError(w, http.StatusBadRequest, "INVALID_EMAIL_TYPE", "type must be one of company, personal or other")
+		return
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	exists, err := employeeExists(ctx, employeeID)
+	if err != nil {
+		writeDBError(w, r, err, "Error adding email")
+		return
+	}
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "EMPLOYEE_NOT_FOUND", "Employee not found")
+		return
+	}
+
+	tx, err := DB.BeginTx(ctx, nil)
+	if err != nil {
+		writeDBError(w, r, err, "Error adding email")
+		return
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO m_employee_email (employee_id, email, email_type)
+			  VALUES ($1, $2, $3) RETURNING id, created_at`
+
+	var createdAt sql.NullTime
+	err = tx.QueryRowContext(ctx, query, employeeID, email.Email, email.Type).Scan(&email.ID, &createdAt)
+	if err != nil {
+		writeDBError(w, r, err, "Error adding email")
+		return
+	}
+
+	if email.IsPrimary {
+		if err := setPrimaryEmail(ctx, tx, employeeID, email.ID, email.Type); err != nil {
+			writeDBError(w, r, err, "Error adding email")
+			return
+		}
+		if email.Type == EmailTypeCompany {
+			if err := syncCompanyEmail(ctx, tx, employeeID, userID); err != nil {
+				writeDBError(w, r, err, "Error adding email")
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		writeDBError(w, r, err, "Error adding email")
+		return
+	}
+
+	email.EmployeeID = employeeID
+	if createdAt.Valid {
+		email.CreatedAt = formatTimestamp(createdAt.Time)
+	}
+
+	writeJSON(w, http.StatusCreated, email)
+}
+
+// SetPrimaryEmployeeEmail godoc
+// @Summary Make an email primary
+// @Description Mark an email as the employee's primary address of its type, demoting the previous one. A primary company address also becomes the employee's main email.
+// @Tags employee
+// @Produce json
+// @Param id path string true "Employee ID (UUID)"
+// @Param emailId path string true "Email ID (UUID)"
+// @Success 200 {object} EmployeeEmail
+// @Failure 400 {object} ErrorResponse "Invalid employee or email ID"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Email not found"
+// @Failure 409 {object} ErrorResponse "An employee with this email already exists"
+// @Failure 500 {object} ErrorResponse "Error updating email"
+// @Security BearerAuth
+// @Router /employee/{id}/emails/{emailId}/primary [put]
+func SetPrimaryEmployeeEmail(w http.ResponseWriter, r *http.Request) {
+	parts := employeePathParts(r)
+	employeeID, emailID := parts[0], parts[2]
+	if !isUUID(employeeID) || !isUUID(emailID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee or email ID")
+		return
+	}
+
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	tx, err := DB.BeginTx(ctx, nil)
+	if err != nil {
+		writeDBError(w, r, err, "Error updating email")
+		return
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + employeeEmailColumns + ` FROM m_employee_email
+			  WHERE id = $1 AND employee_id = $2 FOR UPDATE`
+
+	email, err := scanEmployeeEmail(tx.QueryRowContext(ctx, query, emailID, employeeID))
+	if err == sql.ErrNoRows {
+		writeJSONError(w, http.StatusNotFound, "EMAIL_NOT_FOUND", "Email not found")
+		return
+	}
+	if err != nil {
+		writeDBError(w, r, err, "Error updating email")
+		return
+	}
+
+	if err := setPrimaryEmail(ctx, tx, employeeID, emailID, email.Type); err != nil {
+		writeDBError(w, r, err, "Error updating email")
+		return
+	}
+	if email.Type == EmailTypeCompany {
+		if err := syncCompanyEmail(ctx, tx, employeeID, userID); err != nil {
+			writeDBError(w, r, err, "Error updating email")
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		writeDBError(w, r, err, "Error updating email")
+		return
+	}
+
+	email.IsPrimary = true
+
+	writeJSON(w, http.StatusOK, email)
+}
+
+// DeleteEmployeeEmail godoc
+// @Summary Remove an email from an employee
+// @Description Delete one of an employee's email addresses. A primary address cannot be removed; make another address of its type primary first.
+// @Tags employee
+// @Param id path string true "Employee ID (UUID)"
+// @Param emailId path string true "Email ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorResponse "Invalid employee or email ID"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Email not found"
+// @Failure 409 {object} ErrorResponse "Email is the employee's primary address"
+// @Failure 500 {object} ErrorResponse "Error removing email"
+// @Security BearerAuth
+// @Router /employee/{id}/emails/{emailId} [delete]
+func DeleteEmployeeEmail(w http.ResponseWriter, r *http.Request) {
+	parts := employeePathParts(r)
+	employeeID, emailID := parts[0], parts[2]
+	if !isUUID(employeeID) || !isUUID(emailID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee or email ID")
+		return
+	}
+
+	if _, ok := requireUser(w, r); !ok {
+		return
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	tx, err := DB.BeginTx(ctx, nil)
+	if err != nil {
+		writeDBError(w, r, err, "Error removing email")
+		return
+	}
+	defer tx.Rollback()
+
+	var isPrimary bool
+	err = tx.QueryRowContext(ctx, `SELECT is_primary FROM m_employee_email
+		WHERE id = $1 AND employee_id = $2 FOR UPDATE`, emailID, employeeID).Scan(&isPrimary)
+	if err == sql.ErrNoRows {
+		writeJSONError(w, http.StatusNotFound, "EMAIL_NOT_FOUND", "Email not found")
+		return
+	}
+	if err != nil {
+		writeDBError(w, r, err, "Error removing email")
+		return
+	}
+
+	// Removing a primary address would leave the employee without one, and for a
+	// company address would silently clear the employee's main email
+	if isPrimary {
+		writeJSONError(w, http.StatusConflict, "PRIMARY_EMAIL_DELETE", "A primary email cannot be removed, make another email primary first")
+		return
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM m_employee_email WHERE id = $1`, emailID); err != nil {
+		writeDBError(w, r, err, "Error removing email")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		writeDBError(w, r, err, "Error removing email")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
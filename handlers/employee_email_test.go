@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+
+	"backend/middleware"
+)
+
+const testEmailID = "7c6d5e4f-3a2b-4c1d-8e9f-0a1b2c3d4e5f"
+
+// recordedQuery is one statement run against the fake database with its arguments
+type recordedQuery struct {
+	query string
+	args  []driver.Value
+}
+
+// emailQueries answers the employee email statements, recording every one, with the
+// stored address of testEmailID having the given type and primary flag
+func emailQueries(queries *[]recordedQuery, emailType string, isPrimary bool, syncErr error) fakeQueryFunc {
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	return func(query string, args []driver.Value) fakeResult {
+		*queries = append(*queries, recordedQuery{query, args})
+		switch {
+		case strings.Contains(query, "SELECT EXISTS"):
+			return fakeResult{columns: []string{"exists"}, rows: [][]driver.Value{{true}}}
+		case strings.Contains(query, "INSERT INTO m_employee_email"):
+			return fakeResult{columns: []string{"id", "created_at"}, rows: [][]driver.Value{{testEmailID, created}}}
+		case strings.Contains(query, "SELECT is_primary"):
+			return fakeResult{columns: []string{"is_primary"}, rows: [][]driver.Value{{isPrimary}}}
+		case strings.Contains(query, "FOR UPDATE"):
+			return fakeResult{
+				columns: []string{"id", "employee_id", "email", "email_type", "is_primary", "created_at"},
+				rows:    [][]driver.Value{{testEmailID, testEmployeeID, "somchai@example.com", emailType, isPrimary, created}},
+			}
+		case strings.Contains(query, "UPDATE m_employee SET email"):
+			return fakeResult{err: syncErr}
+		}
+		return fakeResult{}
+	}
+}
+
+// ranQuery returns the first recorded statement containing fragment
+func ranQuery(queries []recordedQuery, fragment string) (recordedQuery, bool) {
+	for _, q := range queries {
+		if strings.Contains(q.query, fragment) {
+			return q, true
+		}
+	}
+	return recordedQuery{}, false
+}
+
+// asUser attaches an authenticated user to the request
+func asUser(r *http.Request, userID string) *http.Request {
+	return r.WithContext(middleware.WithUserID(r.Context(), userID))
+}
+
+func TestAddSecondaryEmployeeEmail(t *testing.T) {
+	var queries []recordedQuery
+	useFakeDB(t, emailQueries(&queries, EmailTypePersonal, false, nil))
+
+	body := `{"email":" Somchai.Home@Example.com ","type":"personal"}`
+	w := httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodPost, "/api/employee/"+testEmployeeID+"/emails", strings.NewReader(body)))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	var email EmployeeEmail
+	json.Unmarshal(w.Body.Bytes(), &email)
+	if email.ID != testEmailID || email.EmployeeID != testEmployeeID || email.Email != "somchai.home@example.com" || email.IsPrimary {
+		t.Errorf("email = %+v", email)
+	}
+	if _, ok := ranQuery(queries, "UPDATE"); ok {
+		t.Error("adding a secondary email changed the primary or the employee's main email")
+	}
+}
+
+func TestSetPrimaryEmployeeEmailSyncsCompanyEmail(t *testing.T) {
+	var queries []recordedQuery
+	useFakeDB(t, emailQueries(&queries, EmailTypeCompany, false, nil))
+
+	path := "/api/employee/" + testEmployeeID + "/emails/" + testEmailID + "/primary"
+	w := httptest.NewRecorder()
+	EmployeeRouter(w, asUser(httptest.NewRequest(http.MethodPut, path, nil), "hr-admin"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	var email EmployeeEmail
+	json.Unmarshal(w.Body.Bytes(), &email)
+	if !email.IsPrimary {
+		t.Errorf("email = %+v, want it primary", email)
+	}
+
+	demote, ok := ranQuery(queries, "SET is_primary = FALSE")
+	if !ok || demote.args[1] != EmailTypeCompany || demote.args[2] != testEmailID {
+		t.Errorf("previous primary not demoted: %+v", demote)
+	}
+	sync, ok := ranQuery(queries, "UPDATE m_employee SET email")
+	if !ok {
+		t.Fatal("the employee's main email was not synced")
+	}
+	if sync.args[0] != testEmployeeID || sync.args[1] != "hr-admin" {
+		t.Errorf("sync args = %v, want the employee and the user", sync.args)
+	}
+}
+
+func TestSetPrimaryPersonalEmailDoesNotSync(t *testing.T) {
+	var queries []recordedQuery
+	useFakeDB(t, emailQueries(&queries, EmailTypePersonal, false, nil))
+
+	w := httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodPut, "/api/employee/"+testEmployeeID+"/emails/"+testEmailID+"/primary", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	if _, ok := ranQuery(queries, "UPDATE m_employee SET email"); ok {
+		t.Error("a personal address was copied onto the employee's main email")
+	}
+}
+
+func TestDeleteEmployeeEmail(t *testing.T) {
+	path := "/api/employee/" + testEmployeeID + "/emails/" + testEmailID
+
+	tests := []struct {
+		name       string
+		isPrimary  bool
+		wantStatus int
+		wantDelete bool
+	}{
+		{"secondary", false, http.StatusNoContent, true},
+		{"primary", true, http.StatusConflict, false},
+	}
+	for _, tt := range tests {
+		var queries []recordedQuery
+		useFakeDB(t, emailQueries(&queries, EmailTypeCompany, tt.isPrimary, nil))
+
+		w := httptest.NewRecorder()
+		EmployeeRouter(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if _, deleted := ranQuery(queries, "DELETE FROM m_employee_email"); deleted != tt.wantDelete {
+			t.Errorf("%s: deleted = %t, want %t", tt.name, deleted, tt.wantDelete)
+		}
+		if tt.isPrimary {
+			if detail := decodeError(t, w); detail.Code != "PRIMARY_EMAIL_DELETE" {
+				t.Errorf("%s: code = %q, want PRIMARY_EMAIL_DELETE", tt.name, detail.Code)
+			}
+		}
+	}
+}
+
+func TestAddPrimaryEmployeeEmailTaken(t *testing.T) {
+	var queries []recordedQuery
+	taken := &pq.Error{Code: "23505", Constraint: "ux_employee_email_lower"}
+	useFakeDB(t, emailQueries(&queries, EmailTypeCompany, false, taken))
+
+	body := `{"email":"suda@example.com","type":"company","is_primary":true}`
+	w := httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodPost, "/api/employee/"+testEmployeeID+"/emails", strings.NewReader(body)))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", w.Code)
+	}
+	if detail := decodeError(t, w); detail.Code != "EMPLOYEE_EMAIL_TAKEN" || detail.Field != "email" {
+		t.Errorf("error = %+v, want EMPLOYEE_EMAIL_TAKEN on email", detail)
+	}
+}
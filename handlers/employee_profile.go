@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+)
+
+// EmployeeProfile is an employee together with the records attached to them
+type EmployeeProfile struct {
+	Employee  Employee           `json:"employee"`
+	Emails    []EmployeeEmail    `json:"emails"`
+	Documents []EmployeeDocument `json:"documents"`
+}
+
+// GetEmployeeProfile godoc
+// @Summary Get an employee's full profile
+// @Description Get an employee together with their email addresses and document metadata in one response
+// @Tags employee
+// @Produce json
+// @Param id path string true "Employee ID (UUID)"
+// @Success 200 {object} EmployeeProfile
+// @Failure 400 {object} ErrorResponse "Invalid employee ID"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 404 {object} ErrorResponse "Employee not found"
+// @Failure 500 {object} ErrorResponse "Error retrieving employee profile"
+// @Security BearerAuth
+// @Router /employee/{id}/full [get]
+func GetEmployeeProfile(w http.ResponseWriter, r *http.Request) {
+	employeeID := employeePathParts(r)[0]
+	if !isUUID(employeeID) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	// The sections are independent, so load them concurrently
+	var profile EmployeeProfile
+	var employeeErr, emailsErr, documentsErr error
+	var wg sync.WaitGroup
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		profile.Employee, employeeErr = fetchEmployee(ctx, employeeID)
+	}()
+	go func() {
+		defer wg.Done()
+		profile.Emails, emailsErr = fetchEmployeeEmails(ctx, employeeID)
+	}()
+	go func() {
+		defer wg.Done()
+		profile.Documents, documentsErr = fetchEmployeeDocuments(ctx, employeeID)
+	}()
+	wg.Wait()
+
+	if employeeErr == sql.ErrNoRows {
+		writeJSONError(w, http.StatusNotFound, "EMPLOYEE_NOT_FOUND", "Employee not found")
+		return
+	}
+	for _, err := range []error{employeeErr, emailsErr, documentsErr} {
+		if err != nil {
+			writeDBError(w, r, err, "Error retrieving employee profile")
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, profile)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// profileQueries answers the three profile sections, with no employee row when found is false
+func profileQueries(found bool, documentsErr error) fakeQueryFunc {
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	return func(query string, args []driver.Value) fakeResult {
+		switch {
+		case strings.Contains(query, "FROM m_employee_email"):
+			return fakeResult{
+				columns: []string{"id", "employee_id", "email", "email_type", "is_primary", "created_at"},
+				rows:    [][]driver.Value{{testEmailID, testEmployeeID, "somchai@example.com", EmailTypeCompany, true, created}},
+			}
+		case strings.Contains(query, "FROM m_employee_document"):
+			return fakeResult{columns: []string{"id", "employee_id", "document_type", "file_name", "content_type", "size_bytes", "uploaded_by", "created_at"}, err: documentsErr}
+		case strings.Contains(query, "FROM m_employee"):
+			result := fakeResult{columns: employeeColumnNames}
+			if found {
+				result.rows = [][]driver.Value{employeeRow(testEmployeeID)}
+			}
+			return result
+		}
+		return fakeResult{}
+	}
+}
+
+func TestGetEmployeeProfile(t *testing.T) {
+	useFakeDB(t, profileQueries(true, nil))
+
+	w := httptest.NewRecorder()
+	EmployeeRouter(w, httptest.NewRequest(http.MethodGet, "/api/employee/"+testEmployeeID+"/full", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+
+	var profile EmployeeProfile
+	json.Unmarshal(w.Body.Bytes(), &profile)
+	if profile.Employee.ID != testEmployeeID || len(profile.Emails) != 1 || profile.Emails[0].ID != testEmailID {
+		t.Errorf("profile = %+v", profile)
+	}
+	// Empty sections are arrays, not null
+	if !strings.Contains(w.Body.String(), `"documents":[]`) {
+		t.Errorf("body %s, want documents as an empty array", w.Body.String())
+	}
+}
+
+func TestGetEmployeeProfileErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		queries    fakeQueryFunc
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid id", http.MethodGet, "/api/employee/42/full", profileQueries(true, nil), http.StatusBadRequest, "INVALID_ID"},
+		{"unknown employee", http.MethodGet, "/api/employee/" + testEmployeeID + "/full", profileQueries(false, nil), http.StatusNotFound, "EMPLOYEE_NOT_FOUND"},
+		{"section fails", http.MethodGet, "/api/employee/" + testEmployeeID + "/full", profileQueries(true, errors.New("connection reset")), http.StatusInternalServerError, "INTERNAL_ERROR"},
+		{"wrong method", http.MethodPost, "/api/employee/" + testEmployeeID + "/full", profileQueries(true, nil), http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED"},
+	}
+	for _, tt := range tests {
+		useFakeDB(t, tt.queries)
+
+		w := httptest.NewRecorder()
+		EmployeeRouter(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if detail := decodeError(t, w); detail.Code != tt.wantCode {
+			t.Errorf("%s: code = %q, want %s", tt.name, detail.Code, tt.wantCode)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// decodeError decodes a JSON error response, failing the test if the body has another shape
+func decodeError(t *testing.T, w *httptest.ResponseRecorder) ErrorDetail {
+	t.Helper()
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decoding body %q: %v", w.Body.String(), err)
+	}
+	return response.Error
+}
+
+// insertedEmployee answers the INSERT ... RETURNING of insertEmployee with the given ID
+func insertedEmployee(id string, created time.Time) fakeResult {
+	return fakeResult{
+		columns: []string{"id", "is_active", "created_at", "updated_at"},
+		rows:    [][]driver.Value{{id, true, created, created}},
+	}
+}
+
+// employeeRow is a row of employeeColumns for the employee with the given ID
+func employeeRow(id string) []driver.Value {
+	created := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+	return []driver.Value{
+		id, "EMP-" + id[:4], "Mr.", "Somchai", "Jaidee", nil,
+		"somchai@example.com", nil, int64(GenderMale), time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "Engineering",
+		"Developer", int64(EmploymentTypeFullTime), true, created, created,
+		nil, nil,
+	}
+}
+
+// employeeColumnNames are the column names of an employeeRow
+var employeeColumnNames = strings.Split(strings.Join(strings.Fields(employeeColumns), ""), ",")
+
+func TestGetEmployeeByIDErrors(t *testing.T) {
+	const id = "4f8c2a9e-1b7d-4c3a-9e5f-6a2b8d0c1e3f"
+
+	tests := []struct {
+		name     string
+		path     string
+		result   fakeResult
+		wantCode int
+		wantErr  string
+	}{
+		{"malformed id", "/api/employee/123", fakeResult{}, http.StatusBadRequest, "INVALID_ID"},
+		{"unknown id", "/api/employee/" + id, fakeResult{columns: []string{"id"}}, http.StatusNotFound, "EMPLOYEE_NOT_FOUND"},
+		{"database error", "/api/employee/" + id, fakeResult{err: errors.New("connection reset")}, http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queried := false
+			useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+				queried = true
+				if len(args) != 1 || args[0] != id {
+					t.Errorf("query args = %v, want [%s]", args, id)
+				}
+				return tt.result
+			})
+
+			w := httptest.NewRecorder()
+			EmployeeRouter(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if detail := decodeError(t, w); detail.Code != tt.wantErr || detail.Message == "" {
+				t.Errorf("error = %+v, want code %s with a message", detail, tt.wantErr)
+			}
+			if queried != (tt.wantCode != http.StatusBadRequest) {
+				t.Errorf("database queried = %t for status %d", queried, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCreateEmployeeTakesAuditFieldsFromToken(t *testing.T) {
+	var insertArgs []driver.Value
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		if strings.Contains(query, "INSERT INTO m_employee (") {
+			insertArgs = args
+			return insertedEmployee(testEmployeeID, time.Now())
+		}
+		return fakeResult{}
+	})
+
+	body := `{"prefix_name":"Mr.","first_name":"Somchai","last_name":"Jaidee","created_by":"mallory","updated_by":"mallory"}`
+	r := asUser(httptest.NewRequest(http.MethodPost, "/api/employee", strings.NewReader(body)), "token-subject")
+	w := httptest.NewRecorder()
+	CreateEmployee(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	if len(insertArgs) != 15 || insertArgs[13] != "token-subject" || insertArgs[14] != "token-subject" {
+		t.Errorf("INSERT args = %v, want the token subject as $14 and $15", insertArgs)
+	}
+	var employee Employee
+	json.Unmarshal(w.Body.Bytes(), &employee)
+	if employee.CreatedBy != "token-subject" || employee.UpdatedBy != "token-subject" {
+		t.Errorf("response created_by/updated_by = %q/%q, want the token subject", employee.CreatedBy, employee.UpdatedBy)
+	}
+}
+
+func TestCreateEmployeeDuplicateEmailIgnoresCase(t *testing.T) {
+	stored := map[string]bool{}
+	var inserted []string
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		if !strings.Contains(query, "INSERT INTO m_employee (") {
+			return fakeResult{}
+		}
+		// Stands in for the unique index on lower(email)
+		email := args[5].(string)
+		inserted = append(inserted, email)
+		if stored[strings.ToLower(email)] {
+			return fakeResult{err: &pq.Error{Code: "23505", Constraint: "ux_employee_email_lower"}}
+		}
+		stored[strings.ToLower(email)] = true
+		return insertedEmployee(testEmployeeID, time.Now())
+	})
+
+	create := func(email string) *httptest.ResponseRecorder {
+		body := `{"prefix_name":"Mr.","first_name":"Somchai","last_name":"Jaidee","email":"` + email + `"}`
+		w := httptest.NewRecorder()
+		CreateEmployee(w, httptest.NewRequest(http.MethodPost, "/api/employee", strings.NewReader(body)))
+		return w
+	}
+
+	if w := create("somchai@example.com"); w.Code != http.StatusCreated {
+		t.Fatalf("first create: status = %d, body %s", w.Code, w.Body.String())
+	}
+	w := create("  Somchai@EXAMPLE.com ")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("second create: status = %d, want 409", w.Code)
+	}
+	if detail := decodeError(t, w); detail.Code != "EMPLOYEE_EMAIL_TAKEN" || detail.Field != "email" {
+		t.Errorf("error = %+v, want EMPLOYEE_EMAIL_TAKEN on email", detail)
+	}
+	if len(inserted) != 2 || inserted[1] != "somchai@example.com" {
+		t.Errorf("inserted emails = %q, want the second one lower-cased and trimmed", inserted)
+	}
+}
+
+func TestEmployeeTimestampsAreUTC(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, bangkok)
+	updated := time.Date(2024, 3, 2, 0, 15, 0, 0, bangkok)
+
+	t.Run("insertEmployee", func(t *testing.T) {
+		useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+			return fakeResult{
+				columns: []string{"id", "is_active", "created_at", "updated_at"},
+				rows:    [][]driver.Value{{testEmployeeID, true, created, updated}},
+			}
+		})
+		tx, err := DB.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tx.Rollback()
+
+		employee := Employee{PrefixName: "Mr.", FirstName: "Somchai", LastName: "Jaidee"}
+		if err := insertEmployee(context.Background(), tx, &employee); err != nil {
+			t.Fatal(err)
+		}
+		if employee.ID != testEmployeeID || !employee.IsActive {
+			t.Errorf("RETURNING id/is_active not applied: %+v", employee)
+		}
+		if employee.CreatedAt != "2024-03-01T02:30:00Z" || employee.UpdatedAt != "2024-03-01T17:15:00Z" {
+			t.Errorf("created_at/updated_at = %s/%s, want RFC3339 in UTC", employee.CreatedAt, employee.UpdatedAt)
+		}
+	})
+
+	t.Run("scanEmployee", func(t *testing.T) {
+		row := employeeRow(testEmployeeID)
+		row[15], row[16] = created, updated
+		useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+			return fakeResult{columns: employeeColumnNames, rows: [][]driver.Value{row}}
+		})
+
+		employee, err := fetchEmployee(context.Background(), testEmployeeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if employee.CreatedAt != "2024-03-01T02:30:00Z" || employee.UpdatedAt != "2024-03-01T17:15:00Z" {
+			t.Errorf("created_at/updated_at = %s/%s, want RFC3339 in UTC", employee.CreatedAt, employee.UpdatedAt)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// EnumValue is one allowed value of a coded employee field with its labels
+type EnumValue struct {
+	Value   int    `json:"value" example:"1"`
+	Label   string `json:"label" example:"Full-time"`
+	LabelTh string `json:"label_th" example:"พนักงานประจำ"`
+}
+
+// enums lists the allowed values of every integer-coded employee field, keyed by JSON field name
+var enums = map[string][]EnumValue{
+	"employment_type": {
+		{EmploymentTypeUnspecified, "Unspecified", "ไม่ระบุ"},
+		{EmploymentTypeFullTime, "Full-time", "พนักงานประจำ"},
+		{EmploymentTypePartTime, "Part-time", "พนักงานพาร์ทไทม์"},
+		{EmploymentTypeContract, "Contract", "พนักงานสัญญาจ้าง"},
+		{EmploymentTypeIntern, "Intern", "นักศึกษาฝึกงาน"},
+	},
+	"gender": {
+		{GenderUnspecified, "Unspecified", "ไม่ระบุ"},
+		{GenderMale, "Male", "ชาย"},
+		{GenderFemale, "Female", "หญิง"},
+	},
+}
+
+// enumAllows reports whether value is one of the allowed values of the named field
+func enumAllows(field string, value int) bool {
+	for _, allowed := range enums[field] {
+		if allowed.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// enumValueList renders the allowed values of the named field for error messages
+func enumValueList(field string) string {
+	values := make([]string, len(enums[field]))
+	for i, allowed := range enums[field] {
+		values[i] = strconv.Itoa(allowed.Value)
+	}
+	return strings.Join(values, ", ")
+}
+
+// GetEnums godoc
+// @Summary List coded field values
+// @Description Get the allowed integer values of employment_type and gender with their English and Thai labels
+// @Tags employee
+// @Produce json
+// @Success 200 {object} map[string][]EnumValue
+// @Failure 400 {object} ErrorResponse "Unknown query parameters"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Security BearerAuth
+// @Router /enums [get]
+func GetEnums(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, enums)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetEnums(t *testing.T) {
+	w := httptest.NewRecorder()
+	GetEnums(w, httptest.NewRequest(http.MethodGet, "/api/enums", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	var got map[string][]EnumValue
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding body %q: %v", w.Body.String(), err)
+	}
+	for field, want := range map[string][]int{
+		"employment_type": {0, 1, 2, 3, 4},
+		"gender":          {0, 1, 2},
+	} {
+		if len(got[field]) != len(want) {
+			t.Errorf("%s: %d values, want %d", field, len(got[field]), len(want))
+			continue
+		}
+		for i, value := range got[field] {
+			if value.Value != want[i] || value.Label == "" || value.LabelTh == "" {
+				t.Errorf("%s[%d] = %+v, want value %d with both labels", field, i, value, want[i])
+			}
+		}
+	}
+}
+
+func TestValidateRejectsUnlistedEnumValues(t *testing.T) {
+	base := Employee{PrefixName: "Mr.", FirstName: "Somchai", LastName: "Jaidee"}
+
+	tests := []struct {
+		name     string
+		employee func(e Employee) Employee
+		field    string
+	}{
+		{"employment_type too high", func(e Employee) Employee { e.EmploymentType = 5; return e }, "employment_type"},
+		{"employment_type negative", func(e Employee) Employee { e.EmploymentType = -1; return e }, "employment_type"},
+		{"gender too high", func(e Employee) Employee { e.Gender = 3; return e }, "gender"},
+		{"gender negative", func(e Employee) Employee { e.Gender = -1; return e }, "gender"},
+	}
+	for _, tt := range tests {
+		errs := tt.employee(base).validate()
+		if len(errs) != 1 || errs[0].Field != tt.field || errs[0].Message != "must be one of "+enumValueList(tt.field) {
+			t.Errorf("%s: validate() = %v, want one %s error listing the allowed values", tt.name, errs, tt.field)
+		}
+	}
+}
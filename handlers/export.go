@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// exportBatchSize is the number of rows fetched from the export cursor, and written before each flush
+const exportBatchSize = 500
+
+// exportCursor is the name of the server-side cursor an export reads through
+const exportCursor = "employee_export"
+
+// ExportEmployees godoc
+// @Summary Export employees
+// @Description Stream all employees as newline-delimited JSON, one employee object per line
+// @Tags employee
+// @Produce application/x-ndjson
+// @Param format query string false "Export format (only ndjson is supported)" default(ndjson)
+// @Success 200 {object} Employee
+// @Failure 400 {object} ErrorResponse "Unsupported export format or unknown query parameters"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 500 {object} ErrorResponse "Error exporting employees"
+// @Security BearerAuth
+// @Router /employees/export [get]
+func ExportEmployees(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r, "format") {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "ndjson"
+	}
+	if format != "ndjson" {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "Unsupported export format: "+format)
+		return
+	}
+
+	// Read through a server-side cursor so only one batch is held in memory at a time,
+	// however many employees there are. Cursors only live inside a transaction.
+	tx, err := DB.BeginTx(r.Context(), &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		writeDBError(w, r, err, "Error exporting employees")
+		return
+	}
+	defer tx.Rollback()
+
+	query := `DECLARE ` + exportCursor + ` NO SCROLL CURSOR FOR
+		SELECT ` + employeeColumns + ` FROM m_employee ORDER BY created_at, id`
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		writeDBError(w, r, err, "Error exporting employees")
+		return
+	}
+
+	// Fetch the first batch before sending the status line so early failures still get a proper error
+	batch, err := fetchExportBatch(r, tx)
+	if err != nil {
+		writeDBError(w, r, err, "Error exporting employees")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	// The status line has already been sent, so failures from here on can only be logged
+	for len(batch) > 0 {
+		for _, employee := range batch {
+			if err := encoder.Encode(employee); err != nil {
+				log.Printf("[%s] Error writing employee export: %v", requestID(w), err)
+				return
+			}
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		// A short batch means the cursor is exhausted
+		if len(batch) < exportBatchSize {
+			break
+		}
+
+		if batch, err = fetchExportBatch(r, tx); err != nil {
+			log.Printf("[%s] Error reading employees during export: %v", requestID(w), err)
+			return
+		}
+	}
+}
+
+// fetchExportBatch reads the next exportBatchSize employees from the export cursor.
+// The query timeout applies per batch, since the export as a whole may run much longer.
+func fetchExportBatch(r *http.Request, tx *sql.Tx) ([]Employee, error) {
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	rows, err := tx.QueryContext(ctx, `FETCH FORWARD `+strconv.Itoa(exportBatchSize)+` FROM `+exportCursor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batch := make([]Employee, 0, exportBatchSize)
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, employee)
+	}
+
+	return batch, rows.Err()
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// exportQueries answers the export cursor statements, serving total employees in
+// FETCH-sized batches and counting how many FETCHes were issued
+func exportQueries(t *testing.T, total int, fetches *int) fakeQueryFunc {
+	served := 0
+	return func(query string, args []driver.Value) fakeResult {
+		switch {
+		case strings.HasPrefix(query, "DECLARE "+exportCursor):
+			return fakeResult{}
+		case strings.HasPrefix(query, "FETCH FORWARD"):
+			*fetches++
+			result := fakeResult{columns: employeeColumnNames}
+			for ; served < total && len(result.rows) < exportBatchSize; served++ {
+				result.rows = append(result.rows, employeeRow(fmt.Sprintf("%08d-0000-4000-8000-000000000000", served)))
+			}
+			return result
+		}
+		t.Errorf("unexpected query %q", query)
+		return fakeResult{}
+	}
+}
+
+// readExportLines decodes every line of an NDJSON export on its own
+func readExportLines(t *testing.T, body string) []Employee {
+	t.Helper()
+	var employees []Employee
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for line := 1; scanner.Scan(); line++ {
+		var employee Employee
+		if err := json.Unmarshal(scanner.Bytes(), &employee); err != nil {
+			t.Fatalf("line %d %q is not a JSON object: %v", line, scanner.Text(), err)
+		}
+		employees = append(employees, employee)
+	}
+	return employees
+}
+
+func TestExportEmployeesNDJSON(t *testing.T) {
+	var fetches int
+	useFakeDB(t, exportQueries(t, 3, &fetches))
+
+	w := httptest.NewRecorder()
+	ExportEmployees(w, httptest.NewRequest(http.MethodGet, "/api/employees/export", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", got)
+	}
+	if !strings.HasSuffix(w.Body.String(), "}\n") {
+		t.Errorf("body does not end with a complete line: %q", w.Body.String())
+	}
+
+	employees := readExportLines(t, w.Body.String())
+	if len(employees) != 3 {
+		t.Fatalf("got %d lines, want one per employee (3)", len(employees))
+	}
+	for i, employee := range employees {
+		if want := fmt.Sprintf("%08d-0000-4000-8000-000000000000", i); employee.ID != want {
+			t.Errorf("line %d: id = %q, want %q in cursor order", i+1, employee.ID, want)
+		}
+	}
+}
+
+func TestExportEmployeesEmpty(t *testing.T) {
+	var fetches int
+	useFakeDB(t, exportQueries(t, 0, &fetches))
+
+	w := httptest.NewRecorder()
+	ExportEmployees(w, httptest.NewRequest(http.MethodGet, "/api/employees/export", nil))
+
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("status = %d, body %q, want an empty 200", w.Code, w.Body.String())
+	}
+}
+
+func TestExportEmployeesRejectsFormat(t *testing.T) {
+	w := httptest.NewRecorder()
+	ExportEmployees(w, httptest.NewRequest(http.MethodGet, "/api/employees/export?format=csv", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
+
+// flushRecorder records how many complete lines had been written at each flush
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	linesAtFlush []int
+}
+
+func (f *flushRecorder) Flush() {
+	f.linesAtFlush = append(f.linesAtFlush, strings.Count(f.Body.String(), "\n"))
+	f.ResponseRecorder.Flush()
+}
+
+func TestExportEmployeesBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		wantFetches int
+		wantFlushes []int
+	}{
+		// A full last batch needs one more FETCH to find the cursor empty
+		{"exact batches", 2 * exportBatchSize, 3, []int{exportBatchSize, 2 * exportBatchSize}},
+		// A short batch ends the export without another FETCH
+		{"partial last batch", 2*exportBatchSize + 7, 3, []int{exportBatchSize, 2 * exportBatchSize, 2*exportBatchSize + 7}},
+	}
+	for _, tt := range tests {
+		var fetches int
+		useFakeDB(t, exportQueries(t, tt.total, &fetches))
+
+		w := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+		ExportEmployees(w, httptest.NewRequest(http.MethodGet, "/api/employees/export", nil))
+
+		if got := len(readExportLines(t, w.Body.String())); got != tt.total {
+			t.Errorf("%s: %d lines, want %d", tt.name, got, tt.total)
+		}
+		if fetches != tt.wantFetches {
+			t.Errorf("%s: %d FETCHes, want %d", tt.name, fetches, tt.wantFetches)
+		}
+		if fmt.Sprint(w.linesAtFlush) != fmt.Sprint(tt.wantFlushes) {
+			t.Errorf("%s: lines written at each flush = %v, want %v", tt.name, w.linesAtFlush, tt.wantFlushes)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeResult is the canned answer to one query run against a fake database
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+// fakeQueryFunc answers every statement run against a fake database
+type fakeQueryFunc func(query string, args []driver.Value) fakeResult
+
+var (
+	fakeDriverOnce sync.Once
+	fakeDBs        sync.Map
+	fakeDBCount    atomic.Int64
+)
+
+// useFakeDB points the package DB at a database whose statements are answered by fn,
+// restoring the previous DB when the test ends
+func useFakeDB(t *testing.T, fn fakeQueryFunc) {
+	t.Helper()
+	fakeDriverOnce.Do(func() { sql.Register("handlers-fake", fakeDriver{}) })
+
+	name := strconv.FormatInt(fakeDBCount.Add(1), 10)
+	fakeDBs.Store(name, fn)
+
+	db, err := sql.Open("handlers-fake", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := DB
+	DB = db
+	t.Cleanup(func() {
+		DB = previous
+		db.Close()
+		fakeDBs.Delete(name)
+	})
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fn, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, errors.New("unknown fake database " + name)
+	}
+	return &fakeConn{fn: fn.(fakeQueryFunc)}, nil
+}
+
+type fakeConn struct{ fn fakeQueryFunc }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+// Ping runs as the statement "PING", so a test can make the database unreachable
+func (c *fakeConn) Ping(ctx context.Context) error {
+	return c.fn("PING", nil).err
+}
+
+// BeginTx accepts any options, such as the read-only transaction of the export
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	result := s.conn.fn(s.query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+	return driver.RowsAffected(len(result.rows)), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	result := s.conn.fn(s.query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+	return &fakeRows{columns: result.columns, rows: result.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// headcountIntervals are the bucket sizes accepted by GetHeadcountHistory
+var headcountIntervals = map[string]bool{
+	"week":    true,
+	"month":   true,
+	"quarter": true,
+	"year":    true,
+}
+
+// maxHeadcountPeriods bounds the number of buckets one request may ask for, ten years of weeks
+const maxHeadcountPeriods = 520
+
+// headcountPeriodCount returns how many buckets of the interval generate_series produces
+// between from and to, starting from the bucket that contains from
+func headcountPeriodCount(from, to time.Time, interval string) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	switch interval {
+	case "week":
+		// date_trunc starts weeks on Monday
+		start := from.AddDate(0, 0, -((int(from.Weekday()) + 6) % 7))
+		// Unix seconds rather than Sub, whose Duration saturates after about 292 years
+		return int((to.Unix()-start.Unix())/86400)/7 + 1
+	case "month":
+		return months + 1
+	case "quarter":
+		return (to.Year()*12+int(to.Month())-1)/3 - (from.Year()*12+int(from.Month())-1)/3 + 1
+	default:
+		return to.Year() - from.Year() + 1
+	}
+}
+
+// HeadcountPeriod is the number of employees hired within one interval
+type HeadcountPeriod struct {
+	PeriodStart string `json:"period_start"`
+	Hires       int    `json:"hires"`
+}
+
+// GetHeadcountHistory godoc
+// @Summary Get hires over time
+// @Description Get the number of employees hired in each interval between two dates, based on hire_date. Intervals without hires are reported with zero.
+// @Tags employee
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to one year before to"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param interval query string false "Bucket size: week, month, quarter or year" default(month)
+// @Success 200 {array} HeadcountPeriod
+// @Failure 400 {object} ErrorResponse "Invalid from, to or interval, or a range of more than 520 intervals"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 500 {object} ErrorResponse "Error retrieving headcount history"
+// @Security BearerAuth
+// @Router /employees/headcount-history [get]
+func GetHeadcountHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r, "from", "to", "interval") {
+		return
+	}
+
+	to := time.Now()
+	if value := r.URL.Query().Get("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "to must be in YYYY-MM-DD format")
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(-1, 0, 0)
+	if value := r.URL.Query().Get("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "from must be in YYYY-MM-DD format")
+			return
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "from must not be after to")
+		return
+	}
+
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = "month"
+	}
+	if !headcountIntervals[interval] {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "interval must be one of week, month, quarter or year")
+		return
+	}
+
+	// Every bucket is built by Postgres on each call, so an open-ended range is refused
+	if count := headcountPeriodCount(from, to, interval); count > maxHeadcountPeriods {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER",
+			"from and to span "+strconv.Itoa(count)+" "+interval+"s, at most "+strconv.Itoa(maxHeadcountPeriods)+" are allowed")
+		return
+	}
+
+	query := `SELECT bucket.period_start, COUNT(e.id)
+			  FROM generate_series(date_trunc($3, $1::date), $2::date, ('1 ' || $3)::interval) AS bucket(period_start)
+			  LEFT JOIN m_employee e
+				ON e.hire_date BETWEEN $1::date AND $2::date
+				AND date_trunc($3, e.hire_date) = bucket.period_start
+			  GROUP BY bucket.period_start
+			  ORDER BY bucket.period_start`
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	rows, err := DB.QueryContext(ctx, query, from.Format("2006-01-02"), to.Format("2006-01-02"), interval)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving headcount history")
+		return
+	}
+	defer rows.Close()
+
+	periods := []HeadcountPeriod{}
+	for rows.Next() {
+		var period HeadcountPeriod
+		var periodStart time.Time
+		if err := rows.Scan(&periodStart, &period.Hires); err != nil {
+			writeDBError(w, r, err, "Error retrieving headcount history")
+			return
+		}
+		period.PeriodStart = periodStart.Format("2006-01-02")
+		periods = append(periods, period)
+	}
+	if err := rows.Err(); err != nil {
+		writeDBError(w, r, err, "Error retrieving headcount history")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, periods)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeadcountPeriodCount(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tests := []struct {
+		from, to, interval string
+		want               int
+	}{
+		// 2024-01-03 is a Wednesday, its week starts on Monday 2024-01-01
+		{"2024-01-03", "2024-01-07", "week", 1},
+		{"2024-01-03", "2024-01-08", "week", 2},
+		{"2024-01-31", "2024-03-01", "month", 3},
+		{"2024-03-31", "2024-04-01", "quarter", 2},
+		{"2023-12-31", "2024-01-01", "year", 2},
+		{"0001-01-01", "2024-01-01", "week", 105556},
+	}
+	for _, tt := range tests {
+		if got := headcountPeriodCount(day(tt.from), day(tt.to), tt.interval); got != tt.want {
+			t.Errorf("%s to %s by %s: %d periods, want %d", tt.from, tt.to, tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestGetHeadcountHistoryBuckets(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	tests := []struct {
+		query   string
+		args    []driver.Value
+		buckets [][]driver.Value
+		want    []HeadcountPeriod
+	}{
+		{
+			"?from=2024-01-03&to=2024-01-20&interval=week",
+			[]driver.Value{"2024-01-03", "2024-01-20", "week"},
+			[][]driver.Value{{day("2024-01-01"), int64(2)}, {day("2024-01-08"), int64(0)}, {day("2024-01-15"), int64(1)}},
+			[]HeadcountPeriod{{"2024-01-01", 2}, {"2024-01-08", 0}, {"2024-01-15", 1}},
+		},
+		{
+			"?from=2024-01-15&to=2024-03-10",
+			[]driver.Value{"2024-01-15", "2024-03-10", "month"},
+			[][]driver.Value{{day("2024-01-01"), int64(4)}, {day("2024-02-01"), int64(0)}, {day("2024-03-01"), int64(3)}},
+			[]HeadcountPeriod{{"2024-01-01", 4}, {"2024-02-01", 0}, {"2024-03-01", 3}},
+		},
+	}
+	for _, tt := range tests {
+		useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+			if !strings.Contains(query, "generate_series") || !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("%s: query args = %v, want %v", tt.query, args, tt.args)
+			}
+			return fakeResult{columns: []string{"period_start", "count"}, rows: tt.buckets}
+		})
+
+		w := httptest.NewRecorder()
+		GetHeadcountHistory(w, httptest.NewRequest(http.MethodGet, "/api/employees/headcount-history"+tt.query, nil))
+
+		var got []HeadcountPeriod
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, body %s", tt.query, w.Code, w.Body.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: periods = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestGetHeadcountHistoryRejectsParameters(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		t.Errorf("unexpected query with args %v", args)
+		return fakeResult{}
+	})
+
+	for _, query := range []string{
+		"?from=0001-01-01&to=2024-01-01&interval=week",
+		"?from=1900-01-01&to=2024-01-01&interval=month",
+		"?from=2024-02-01&to=2024-01-01",
+		"?from=01/02/2024",
+		"?interval=day",
+	} {
+		w := httptest.NewRecorder()
+		GetHeadcountHistory(w, httptest.NewRequest(http.MethodGet, "/api/employees/headcount-history"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+		} else if detail := decodeError(t, w); detail.Code != "INVALID_QUERY_PARAMETER" {
+			t.Errorf("%s: code = %q", query, detail.Code)
+		}
+	}
+
+	// The cap itself is allowed
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		return fakeResult{columns: []string{"period_start", "count"}}
+	})
+	w := httptest.NewRecorder()
+	GetHeadcountHistory(w, httptest.NewRequest(http.MethodGet, "/api/employees/headcount-history?from=1981-01-01&to=2024-04-30&interval=month", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("520 months: status = %d, want 200", w.Code)
+	}
+}
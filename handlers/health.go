@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// healthPingTimeout bounds the database ping so a stalled database cannot hang the health check
+const healthPingTimeout = 2 * time.Second
+
+// startedAt is when the process started, for reporting uptime
+var startedAt = time.Now()
+
+type HealthStatus struct {
+	Status        string `json:"status" example:"ok"`
+	DB            string `json:"db" example:"up"`
+	Version       string `json:"version" example:"1.2.0"`
+	UptimeSeconds int64  `json:"uptime_seconds" example:"3600"`
+}
+
+// HealthCheck godoc
+// @Summary Check service health
+// @Description Ping the database and report whether the service is healthy, with its version and uptime
+// @Tags system
+// @Produce json
+// @Success 200 {object} HealthStatus
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 503 {object} HealthStatus "Database unreachable"
+// @Router /health [get]
+func HealthCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+	defer cancel()
+
+	status := HealthStatus{
+		Status:        "ok",
+		DB:            "up",
+		Version:       Version,
+		UptimeSeconds: int64(time.Since(startedAt).Seconds()),
+	}
+	code := http.StatusOK
+	if err := DB.PingContext(ctx); err != nil {
+		status.Status = "degraded"
+		status.DB = "down"
+		code = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, code, status)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		pingErr    error
+		wantStatus int
+		want       HealthStatus
+	}{
+		{"database up", nil, http.StatusOK, HealthStatus{Status: "ok", DB: "up"}},
+		{"database down", errors.New("connection refused"), http.StatusServiceUnavailable, HealthStatus{Status: "degraded", DB: "down"}},
+	}
+	for _, tt := range tests {
+		useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+			return fakeResult{err: tt.pingErr}
+		})
+
+		w := httptest.NewRecorder()
+		HealthCheck(w, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		var got HealthStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s: decoding body %q: %v", tt.name, w.Body.String(), err)
+		}
+		if got.Status != tt.want.Status || got.DB != tt.want.DB || got.Version != Version || got.UptimeSeconds < 0 {
+			t.Errorf("%s: body = %+v, want status %s and db %s", tt.name, got, tt.want.Status, tt.want.DB)
+		}
+	}
+}
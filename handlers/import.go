@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportMaxBytes is the largest CSV file accepted by the employee import
+var ImportMaxBytes int64 = 5 << 20
+
+// importContentTypes are the declared content types accepted for an import file.
+// Browsers on Windows commonly label .csv files as application/vnd.ms-excel.
+var importContentTypes = []string{"text/csv", "application/csv", "application/vnd.ms-excel"}
+
+// importColumns maps each writable CSV column, named like the JSON fields, to the Employee field it sets
+var importColumns = map[string]func(e *Employee, value string) error{
+	"employee_code": func(e *Employee, v string) error { e.EmployeeCode = v; return nil },
+	"prefix_name":   func(e *Employee, v string) error { e.PrefixName = v; return nil },
+	"first_name":    func(e *Employee, v string) error { e.FirstName = v; return nil },
+	"last_name":     func(e *Employee, v string) error { e.LastName = v; return nil },
+	"nickname":      func(e *Employee, v string) error { e.Nickname = v; return nil },
+	"email":         func(e *Employee, v string) error { e.Email = normalizeEmail(v); return nil },
+	"phone_number":  func(e *Employee, v string) error { e.PhoneNumber = v; return nil },
+	"gender":        func(e *Employee, v string) error { return parseImportInt(v, &e.Gender) },
+	"birth_date":    func(e *Employee, v string) error { e.BirthDate = v; return nil },
+	"hire_date":     func(e *Employee, v string) error { e.HireDate = v; return nil },
+	"department":    func(e *Employee, v string) error { e.Department = v; return nil },
+	"position":      func(e *Employee, v string) error { e.Position = v; return nil },
+	"employment_type": func(e *Employee, v string) error {
+		return parseImportInt(v, &e.EmploymentType)
+	},
+}
+
+// importIgnoredColumns are exported columns the database assigns, skipped so an export can be re-imported
+var importIgnoredColumns = map[string]bool{
+	"id": true, "is_active": true, "created_at": true, "updated_at": true, "created_by": true, "updated_by": true,
+}
+
+// parseImportInt parses an optional integer cell, leaving dst untouched when it is empty
+func parseImportInt(value string, dst *int) error {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return errors.New("must be an integer")
+	}
+	*dst = n
+	return nil
+}
+
+type ImportError struct {
+	Line    int    `json:"line" example:"3"`
+	Message string `json:"message" example:"first_name is required"`
+}
+
+type ImportSummary struct {
+	Inserted int           `json:"inserted"`
+	Failed   int           `json:"failed"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// ImportEmployees godoc
+// @Summary Import employees from CSV
+// @Description Create employees from an uploaded CSV file whose header row names the columns, using the same names as the export. Read-only columns such as id and created_at are ignored. Valid rows are inserted in one transaction; invalid rows are skipped and reported with their line number.
+// @Tags employee
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Success 200 {object} ImportSummary
+// @Failure 400 {object} ErrorResponse "Invalid form, missing file or unusable header row"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 413 {object} ErrorResponse "File too large"
+// @Failure 415 {object} ErrorResponse "File is not a CSV"
+// @Failure 500 {object} ErrorResponse "Error importing employees"
+// @Security BearerAuth
+// @Router /employees/import [post]
+func ImportEmployees(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	// Leave headroom for the multipart envelope
+	r.Body = http.MaxBytesReader(w, r.Body, ImportMaxBytes+1<<20)
+	if err := r.ParseMultipartForm(ImportMaxBytes); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "IMPORT_TOO_LARGE", "File exceeds the "+strconv.FormatInt(ImportMaxBytes, 10)+" byte limit")
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, "INVALID_BODY", "Invalid multipart form: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_FIELD", "file is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > ImportMaxBytes {
+		writeJSONError(w, http.StatusRequestEntityTooLarge, "IMPORT_TOO_LARGE", "File exceeds the "+strconv.FormatInt(ImportMaxBytes, 10)+" byte limit")
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if !importContentTypeAllowed(contentType) {
+		writeJSONError(w, http.StatusUnsupportedMediaType, "UNSUPPORTED_IMPORT_TYPE", "File must be a CSV, got "+contentType)
+		return
+	}
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	columns, err := reader.Read()
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_IMPORT_HEADER", "File must start with a header row")
+		return
+	}
+	// Spreadsheet programs often save CSV with a byte order mark
+	columns[0] = strings.TrimPrefix(columns[0], "\ufeff")
+	if message := checkImportHeader(columns); message != "" {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_IMPORT_HEADER", message)
+		return
+	}
+
+	// The import route is exempt from the request timeout, so the transaction lives as long
+	// as the request while each statement gets its own query timeout. A large file is then
+	// not cut short by either limit.
+	tx, err := DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		writeDBError(w, r, err, "Error importing employees")
+		return
+	}
+	defer tx.Rollback()
+
+	rows, rowErrs, err := readImportRows(reader, columns)
+	if err != nil {
+		writeInternalError(w, "Error reading import file", err)
+		return
+	}
+	summary := ImportSummary{Failed: len(rowErrs), Errors: rowErrs}
+
+	for _, row := range rows {
+		row.employee.CreatedBy = userID
+		row.employee.UpdatedBy = userID
+
+		if err := insertImportRow(r, tx, &row.employee); err != nil {
+			dbMessage, known := lookupDBError(err)
+			if !known || dbMessage.status == http.StatusServiceUnavailable {
+				writeDBError(w, r, err, "Error importing employees")
+				return
+			}
+			if err := execImportStatement(r, tx, `ROLLBACK TO SAVEPOINT import_row`); err != nil {
+				writeDBError(w, r, err, "Error importing employees")
+				return
+			}
+
+			message := dbMessage.en
+			if prefersThai(r) {
+				message = dbMessage.th
+			}
+			summary.Failed++
+			summary.Errors = append(summary.Errors, ImportError{Line: row.line, Message: message})
+			continue
+		}
+		summary.Inserted++
+	}
+
+	if err := tx.Commit(); err != nil {
+		writeDBError(w, r, err, "Error importing employees")
+		return
+	}
+
+	// Parse errors and database errors were collected separately, report them in file order
+	sort.SliceStable(summary.Errors, func(i, j int) bool { return summary.Errors[i].Line < summary.Errors[j].Line })
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// insertImportRow inserts one employee under a savepoint, so a constraint violation can
+// skip the row without aborting the import
+func insertImportRow(r *http.Request, tx *sql.Tx, employee *Employee) error {
+	if err := execImportStatement(r, tx, `SAVEPOINT import_row`); err != nil {
+		return err
+	}
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+	return insertEmployee(ctx, tx, employee)
+}
+
+// execImportStatement runs a statement of the import transaction under its own query timeout
+func execImportStatement(r *http.Request, tx *sql.Tx, query string) error {
+	ctx, cancel := queryContext(r)
+	defer cancel()
+	_, err := tx.ExecContext(ctx, query)
+	return err
+}
+
+// importRow is a parsed, valid CSV record with the line it started on
+type importRow struct {
+	line     int
+	employee Employee
+}
+
+// readImportRows reads every record after the header, returning the valid rows and an
+// error for each invalid one. The error return is only set when the file itself cannot be read.
+func readImportRows(reader *csv.Reader, columns []string) ([]importRow, []ImportError, error) {
+	var rows []importRow
+	errs := []ImportError{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, err
+			}
+			errs = append(errs, ImportError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+			// A wrong field count leaves the reader usable, anything else cannot be recovered from
+			if errors.Is(err, csv.ErrFieldCount) {
+				continue
+			}
+			break
+		}
+
+		// Only a successful read has field positions to report
+		line, _ := reader.FieldPos(0)
+		employee, message := parseImportRecord(columns, record)
+		if message != "" {
+			errs = append(errs, ImportError{Line: line, Message: message})
+			continue
+		}
+		rows = append(rows, importRow{line: line, employee: employee})
+	}
+
+	return rows, errs, nil
+}
+
+// importContentTypeAllowed reports whether the declared type of an uploaded file marks it as CSV
+func importContentTypeAllowed(contentType string) bool {
+	for _, allowed := range importContentTypes {
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// checkImportHeader returns why a header row cannot be imported, or "" if it can
+func checkImportHeader(columns []string) string {
+	seen := make(map[string]bool)
+	var unknown, duplicate []string
+	for _, column := range columns {
+		if _, ok := importColumns[column]; !ok && !importIgnoredColumns[column] {
+			unknown = append(unknown, column)
+		}
+		if seen[column] {
+			duplicate = append(duplicate, column)
+		}
+		seen[column] = true
+	}
+
+	switch {
+	case len(unknown) > 0:
+		return "Unknown columns: " + strings.Join(unknown, ", ")
+	case len(duplicate) > 0:
+		return "Duplicate columns: " + strings.Join(duplicate, ", ")
+	case !seen["prefix_name"] || !seen["first_name"] || !seen["last_name"]:
+		return "Header must include prefix_name, first_name and last_name"
+	}
+	return ""
+}
+
+// parseImportRecord builds an employee from one CSV record and validates it, returning
+// every problem with the row as a single message
+func parseImportRecord(columns, record []string) (Employee, string) {
+	var employee Employee
+	var problems []string
+	for i, column := range columns {
+		set, ok := importColumns[column]
+		if !ok {
+			continue
+		}
+		if err := set(&employee, strings.TrimSpace(record[i])); err != nil {
+			problems = append(problems, column+" "+err.Error())
+		}
+	}
+
+	for _, fieldErr := range employee.validate() {
+		problems = append(problems, fieldErr.Field+" "+fieldErr.Message)
+	}
+
+	return employee, strings.Join(problems, "; ")
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func readTestImport(t *testing.T, content string) ([]importRow, []ImportError) {
+	t.Helper()
+	reader := csv.NewReader(strings.NewReader(content))
+	columns, err := reader.Read()
+	if err != nil {
+		t.Fatalf("reading header: %v", err)
+	}
+	rows, errs, err := readImportRows(reader, columns)
+	if err != nil {
+		t.Fatalf("readImportRows returned error: %v", err)
+	}
+	return rows, errs
+}
+
+func TestReadImportRowsValid(t *testing.T) {
+	rows, errs := readTestImport(t, "prefix_name,first_name,last_name,email\nMr,Somchai,Jaidee,Somchai@Example.com\nMs,Suda,Rakdee,\n")
+
+	if len(errs) != 0 {
+		t.Fatalf("errs = %v, want none", errs)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].line != 2 || rows[1].line != 3 {
+		t.Errorf("lines = %d, %d, want 2, 3", rows[0].line, rows[1].line)
+	}
+	if rows[0].employee.Email != "somchai@example.com" {
+		t.Errorf("email = %q, want it normalized", rows[0].employee.Email)
+	}
+}
+
+func TestReadImportRowsInvalidRow(t *testing.T) {
+	rows, errs := readTestImport(t, "prefix_name,first_name,last_name,gender\nMr,Somchai,Jaidee,1\nMr,,Jaidee,x\n")
+
+	if len(rows) != 1 {
+		t.Fatalf("got %d valid rows, want 1", len(rows))
+	}
+	if len(errs) != 1 || errs[0].Line != 3 {
+		t.Fatalf("errs = %v, want one error on line 3", errs)
+	}
+	for _, want := range []string{"gender must be an integer", "first_name is required"} {
+		if !strings.Contains(errs[0].Message, want) {
+			t.Errorf("message %q does not mention %q", errs[0].Message, want)
+		}
+	}
+}
+
+func TestReadImportRowsMalformed(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantRows int
+		wantLine int
+	}{
+		{"bare quote in first field", "prefix_name,first_name,last_name\nMr,A,B\na\"b,c,d\n", 1, 3},
+		{"unterminated quoted field", "prefix_name,first_name,last_name\nMr,A,B\n\"Mr,A,B\n", 1, 3},
+		{"wrong field count", "prefix_name,first_name,last_name\nMr,A\nMr,A,B\n", 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, errs := readTestImport(t, tt.content)
+			if len(rows) != tt.wantRows {
+				t.Errorf("got %d valid rows, want %d", len(rows), tt.wantRows)
+			}
+			if len(errs) != 1 || errs[0].Line != tt.wantLine {
+				t.Errorf("errs = %v, want one error on line %d", errs, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestCheckImportHeader(t *testing.T) {
+	tests := []struct {
+		columns []string
+		want    string
+	}{
+		{[]string{"id", "prefix_name", "first_name", "last_name", "created_at"}, ""},
+		{[]string{"prefix_name", "first_name", "last_name", "salary"}, "Unknown columns: salary"},
+		{[]string{"prefix_name", "first_name", "last_name", "email", "email"}, "Duplicate columns: email"},
+		{[]string{"first_name", "last_name"}, "Header must include prefix_name, first_name and last_name"},
+	}
+
+	for _, tt := range tests {
+		if got := checkImportHeader(tt.columns); got != tt.want {
+			t.Errorf("checkImportHeader(%v) = %q, want %q", tt.columns, got, tt.want)
+		}
+	}
+}
+
+// importRequest builds a multipart upload of content as the import file
+func importRequest(t *testing.T, contentType, content string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="employees.csv"`)
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(content))
+	form.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/api/employees/import", &body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	return r
+}
+
+func TestImportEmployees(t *testing.T) {
+	var savepoints, rollbacks int
+	inserted := 0
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		switch {
+		case strings.Contains(query, "ROLLBACK TO SAVEPOINT"):
+			rollbacks++
+		case strings.Contains(query, "SAVEPOINT"):
+			savepoints++
+		case strings.Contains(query, "INSERT INTO m_employee ("):
+			if args[5] == "taken@example.com" {
+				return fakeResult{err: &pq.Error{Code: "23505", Constraint: "ux_employee_email_lower"}}
+			}
+			inserted++
+			return insertedEmployee(fmt.Sprintf("00000000-0000-4000-8000-%012d", inserted), time.Now())
+		}
+		return fakeResult{}
+	})
+
+	content := "prefix_name,first_name,last_name,email\n" +
+		"Mr,Somchai,Jaidee,somchai@example.com\n" +
+		"Ms,,Rakdee,\n" +
+		"Ms,Suda,Rakdee,Taken@Example.com\n" +
+		"Ms,Malee,Srisuk,\n"
+	w := httptest.NewRecorder()
+	ImportEmployees(w, importRequest(t, "text/csv", content))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	var summary ImportSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("decoding body %q: %v", w.Body.String(), err)
+	}
+	if summary.Inserted != 2 || summary.Failed != 2 {
+		t.Errorf("summary = %+v, want 2 inserted and 2 failed", summary)
+	}
+	want := []ImportError{
+		{Line: 3, Message: "first_name is required"},
+		{Line: 4, Message: "An employee with this email already exists"},
+	}
+	if !reflect.DeepEqual(summary.Errors, want) {
+		t.Errorf("errors = %v, want %v", summary.Errors, want)
+	}
+	if savepoints != 3 || rollbacks != 1 {
+		t.Errorf("%d savepoints and %d rollbacks, want one savepoint per valid row and one rollback", savepoints, rollbacks)
+	}
+}
+
+func TestImportEmployeesRejectsFile(t *testing.T) {
+	previous := ImportMaxBytes
+	ImportMaxBytes = 64
+	t.Cleanup(func() { ImportMaxBytes = previous })
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		t.Errorf("unexpected query %q for a rejected file", query)
+		return fakeResult{}
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     string
+		wantStatus  int
+		wantCode    string
+	}{
+		{"too large", "text/csv", "prefix_name,first_name,last_name\n" + strings.Repeat("Mr,Somchai,Jaidee\n", 10), http.StatusRequestEntityTooLarge, "IMPORT_TOO_LARGE"},
+		{"not csv", "application/pdf", "%PDF-1.4", http.StatusUnsupportedMediaType, "UNSUPPORTED_IMPORT_TYPE"},
+		{"bad header", "text/csv", "name,surname\n", http.StatusBadRequest, "INVALID_IMPORT_HEADER"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		ImportEmployees(w, importRequest(t, tt.contentType, tt.content))
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if detail := decodeError(t, w); detail.Code != tt.wantCode {
+			t.Errorf("%s: code = %q, want %s", tt.name, detail.Code, tt.wantCode)
+		}
+	}
+}
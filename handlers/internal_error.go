@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+
+	"backend/middleware"
+)
+
+// MaskInternalErrors hides the cause of 500 responses from clients; it is turned on when APP_ENV is production
+var MaskInternalErrors bool
+
+// writeInternalError answers with a 500. The cause is always logged under the request ID,
+// which the response carries too; clients only see the cause when errors are not masked.
+func writeInternalError(w http.ResponseWriter, context string, err error) {
+	id := requestID(w)
+	if id == "" {
+		id = newCorrelationID()
+		w.Header().Set(middleware.RequestIDHeader, id)
+	}
+	log.Printf("[%s] %s: %v", id, context, err)
+
+	if MaskInternalErrors {
+		writeJSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", context)
+		return
+	}
+	writeJSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", context+": "+err.Error())
+}
+
+// newCorrelationID returns a short random hex ID for matching a response to its log line,
+// used when the request did not pass through the RequestID middleware
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unavailable"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/middleware"
+)
+
+func TestWriteInternalError(t *testing.T) {
+	t.Cleanup(func() { MaskInternalErrors = false })
+
+	tests := []struct {
+		name        string
+		masked      bool
+		wantMessage string
+	}{
+		{"detailed", false, "Error retrieving employee: pq: relation \"m_employee\" does not exist"},
+		{"masked", true, "Error retrieving employee"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			MaskInternalErrors = tt.masked
+			w := httptest.NewRecorder()
+			w.Header().Set(middleware.RequestIDHeader, "req-500")
+			writeInternalError(w, "Error retrieving employee", errors.New(`pq: relation "m_employee" does not exist`))
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want 500", w.Code)
+			}
+			detail := decodeError(t, w)
+			if detail.Code != "INTERNAL_ERROR" || detail.Message != tt.wantMessage {
+				t.Errorf("error = %+v, want INTERNAL_ERROR %q", detail, tt.wantMessage)
+			}
+			if detail.RequestID != "req-500" {
+				t.Errorf("request_id = %q, want req-500", detail.RequestID)
+			}
+		})
+	}
+}
+
+func TestWriteInternalErrorWithoutRequestID(t *testing.T) {
+	MaskInternalErrors = true
+	t.Cleanup(func() { MaskInternalErrors = false })
+
+	w := httptest.NewRecorder()
+	writeInternalError(w, "Error encoding response", errors.New("json: unsupported type"))
+
+	id := w.Header().Get(middleware.RequestIDHeader)
+	if id == "" {
+		t.Fatal("no correlation ID was set on the response")
+	}
+	if detail := decodeError(t, w); detail.RequestID != id {
+		t.Errorf("request_id = %q, want the header's %q", detail.RequestID, id)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// prefersXML reports whether the Accept header ranks XML above JSON. JSON wins ties
+// and is the default when the header is missing.
+func prefersXML(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+
+	jsonQ, xmlQ := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			jsonQ = max(jsonQ, q)
+		case "application/xml", "text/xml":
+			xmlQ = max(xmlQ, q)
+		}
+	}
+
+	return xmlQ > jsonQ
+}
+
+// writeNegotiated encodes payload as XML when the client prefers it and as JSON otherwise
+func writeNegotiated(w http.ResponseWriter, r *http.Request, status int, payload interface{}) {
+	if prefersXML(r) {
+		body, err := xml.Marshal(payload)
+		if err != nil {
+			writeInternalError(w, "Error encoding response", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(status)
+		if _, err := w.Write(append([]byte(xml.Header), body...)); err != nil {
+			log.Printf("[%s] Error writing response: %v", requestID(w), err)
+		}
+		return
+	}
+
+	writeJSON(w, status, payload)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPrefersXML(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"application/json", false},
+		{"application/xml", true},
+		{"text/xml", true},
+		{"*/*", false},
+		{"application/json, application/xml", false},
+		{"application/xml, application/json", false},
+		{"application/json;q=0.5, application/xml", true},
+		{"application/xml;q=0.9, application/json;q=0.8", true},
+		{"application/xml;q=0.5, */*;q=0.8", false},
+		{"application/xml, not/a type;;", true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/api/employee/x", nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		if got := prefersXML(r); got != tt.want {
+			t.Errorf("Accept %q: prefersXML = %t, want %t", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestWriteNegotiated(t *testing.T) {
+	employee := Employee{ID: testEmployeeID, PrefixName: "Mr.", FirstName: "Somchai", LastName: "Jaidee"}
+
+	t.Run("xml", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/employee/"+testEmployeeID, nil)
+		r.Header.Set("Accept", "application/xml")
+		w := httptest.NewRecorder()
+		writeNegotiated(w, r, http.StatusOK, employee)
+
+		if got := w.Header().Get("Content-Type"); got != "application/xml" {
+			t.Errorf("Content-Type = %q, want application/xml", got)
+		}
+		if !strings.HasPrefix(w.Body.String(), xml.Header+"<employee>") {
+			t.Errorf("body = %q, want an XML declaration and an <employee> root", w.Body.String())
+		}
+		var decoded Employee
+		if err := xml.Unmarshal(w.Body.Bytes(), &decoded); err != nil || decoded.FirstName != "Somchai" {
+			t.Errorf("decoded %+v, %v", decoded, err)
+		}
+	})
+
+	t.Run("json default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		writeNegotiated(w, httptest.NewRequest(http.MethodGet, "/api/employee/"+testEmployeeID, nil), http.StatusOK, employee)
+
+		if got := w.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+		var decoded Employee
+		if err := json.Unmarshal(w.Body.Bytes(), &decoded); err != nil || decoded.FirstName != "Somchai" {
+			t.Errorf("decoded %+v, %v", decoded, err)
+		}
+	})
+}
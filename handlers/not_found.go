@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NotFound answers requests that match no route. API paths get a JSON error like the
+// rest of the API, anything else gets the standard plain-text 404.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api" && !strings.HasPrefix(r.URL.Path, "/api/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSONError(w, http.StatusNotFound, "ROUTE_NOT_FOUND", "No route matches "+r.Method+" "+r.URL.Path)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNotFoundAPIPathsGetJSON(t *testing.T) {
+	for _, path := range []string{"/api", "/api/unknown", "/api/employees/unknown"} {
+		w := httptest.NewRecorder()
+		NotFound(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: status = %d, want 404", path, w.Code)
+		}
+		want := "No route matches DELETE " + path
+		if detail := decodeError(t, w); detail.Code != "ROUTE_NOT_FOUND" || detail.Message != want {
+			t.Errorf("%s: error = %+v, want ROUTE_NOT_FOUND %q", path, detail, want)
+		}
+	}
+}
+
+func TestNotFoundOtherPathsGetPlainText(t *testing.T) {
+	for _, path := range []string{"/", "/favicon.ico", "/apidocs"} {
+		w := httptest.NewRecorder()
+		NotFound(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: status = %d, want 404", path, w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+			t.Errorf("%s: Content-Type = %q, want text/plain", path, got)
+		}
+		if got := strings.TrimSpace(w.Body.String()); got != "404 page not found" {
+			t.Errorf("%s: body = %q, want the standard 404", path, got)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// requirableField describes how to tell that an employee field has not been filled in
+type requirableField struct {
+	missingSQL string
+	missing    func(Employee) bool
+}
+
+// requirableFields lists the optional employee fields that onboarding or an employment type can require
+var requirableFields = map[string]requirableField{
+	"employee_code":   {`COALESCE(employee_code, '') = ''`, func(e Employee) bool { return e.EmployeeCode == "" }},
+	"nickname":        {`COALESCE(nickname, '') = ''`, func(e Employee) bool { return e.Nickname == "" }},
+	"email":           {`COALESCE(email, '') = ''`, func(e Employee) bool { return e.Email == "" }},
+	"phone_number":    {`COALESCE(phone_number, '') = ''`, func(e Employee) bool { return e.PhoneNumber == "" }},
+	"birth_date":      {`birth_date IS NULL`, func(e Employee) bool { return e.BirthDate == "" }},
+	"hire_date":       {`hire_date IS NULL`, func(e Employee) bool { return e.HireDate == "" }},
+	"department":      {`COALESCE(department, '') = ''`, func(e Employee) bool { return e.Department == "" }},
+	"position":        {`COALESCE(position, '') = ''`, func(e Employee) bool { return e.Position == "" }},
+	"employment_type": {`COALESCE(employment_type, 0) = 0`, func(e Employee) bool { return e.EmploymentType == 0 }},
+}
+
+// onboardingRequiredFields are the fields an employee must have filled in to complete onboarding
+var onboardingRequiredFields = []string{"email", "phone_number", "birth_date", "hire_date", "department", "position"}
+
+// SetOnboardingRequiredFields replaces the fields required to complete onboarding
+func SetOnboardingRequiredFields(fields []string) error {
+	for _, field := range fields {
+		if _, ok := requirableFields[field]; !ok {
+			return fmt.Errorf("unknown onboarding field %q", field)
+		}
+	}
+	onboardingRequiredFields = fields
+	return nil
+}
+
+type OnboardingStatus struct {
+	Employee      Employee `json:"employee"`
+	MissingFields []string `json:"missing_fields"`
+}
+
+// GetOnboardingIncomplete godoc
+// @Summary Get employees with incomplete onboarding
+// @Description Get active employees missing any of the required onboarding fields, each with the list of fields still missing
+// @Tags employee
+// @Produce json
+// @Success 200 {array} OnboardingStatus
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 500 {object} ErrorResponse "Error retrieving employees"
+// @Security BearerAuth
+// @Router /employees/onboarding-incomplete [get]
+func GetOnboardingIncomplete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r) {
+		return
+	}
+
+	statuses := []OnboardingStatus{}
+	if len(onboardingRequiredFields) == 0 {
+		writeJSON(w, http.StatusOK, statuses)
+		return
+	}
+
+	conditions := make([]string, 0, len(onboardingRequiredFields))
+	for _, field := range onboardingRequiredFields {
+		conditions = append(conditions, requirableFields[field].missingSQL)
+	}
+
+	query := `SELECT ` + employeeColumns + ` FROM m_employee
+			  WHERE is_active = TRUE AND (` + strings.Join(conditions, " OR ") + `)
+			  ORDER BY created_at, id`
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	rows, err := DB.QueryContext(ctx, query)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving employees")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			writeDBError(w, r, err, "Error retrieving employees")
+			return
+		}
+
+		status := OnboardingStatus{Employee: employee, MissingFields: []string{}}
+		for _, field := range onboardingRequiredFields {
+			if requirableFields[field].missing(employee) {
+				status.MissingFields = append(status.MissingFields, field)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		writeDBError(w, r, err, "Error retrieving employees")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, statuses)
+}
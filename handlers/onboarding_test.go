@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// withOnboardingFields sets the required onboarding fields for one test
+func withOnboardingFields(t *testing.T, fields []string) {
+	t.Helper()
+	previous := onboardingRequiredFields
+	if err := SetOnboardingRequiredFields(fields); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { onboardingRequiredFields = previous })
+}
+
+func getOnboardingIncomplete(t *testing.T) []OnboardingStatus {
+	t.Helper()
+	w := httptest.NewRecorder()
+	GetOnboardingIncomplete(w, httptest.NewRequest(http.MethodGet, "/api/employees/onboarding-incomplete", nil))
+
+	var statuses []OnboardingStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &statuses); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	return statuses
+}
+
+func TestGetOnboardingIncomplete(t *testing.T) {
+	withOnboardingFields(t, []string{"email", "phone_number", "nickname", "department"})
+
+	var gotQuery string
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		gotQuery = query
+		return fakeResult{columns: employeeColumnNames, rows: [][]driver.Value{employeeRow(testEmployeeID)}}
+	})
+
+	statuses := getOnboardingIncomplete(t)
+	if len(statuses) != 1 || statuses[0].Employee.ID != testEmployeeID {
+		t.Fatalf("statuses = %+v", statuses)
+	}
+	// The employee row has no phone number or nickname
+	if want := []string{"phone_number", "nickname"}; !reflect.DeepEqual(statuses[0].MissingFields, want) {
+		t.Errorf("missing_fields = %v, want %v", statuses[0].MissingFields, want)
+	}
+	for _, field := range []string{"email", "phone_number", "nickname", "department"} {
+		if !strings.Contains(gotQuery, requirableFields[field].missingSQL) {
+			t.Errorf("query does not check %s", field)
+		}
+	}
+	if strings.Contains(gotQuery, requirableFields["position"].missingSQL) {
+		t.Error("query checks position, which is not required")
+	}
+}
+
+func TestGetOnboardingIncompleteNoRequiredFields(t *testing.T) {
+	withOnboardingFields(t, []string{})
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		t.Errorf("unexpected query %q", query)
+		return fakeResult{}
+	})
+
+	if statuses := getOnboardingIncomplete(t); len(statuses) != 0 {
+		t.Errorf("statuses = %+v, want none", statuses)
+	}
+}
+
+func TestSetOnboardingRequiredFieldsRejectsUnknown(t *testing.T) {
+	previous := onboardingRequiredFields
+	t.Cleanup(func() { onboardingRequiredFields = previous })
+
+	if err := SetOnboardingRequiredFields([]string{"email", "shoe_size"}); err == nil || !strings.Contains(err.Error(), "shoe_size") {
+		t.Errorf("error = %v, want one naming shoe_size", err)
+	}
+	if !reflect.DeepEqual(onboardingRequiredFields, previous) {
+		t.Errorf("fields changed to %v after a rejected update", onboardingRequiredFields)
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// StrictQueryParams enables rejection of query parameters an endpoint does not recognise
+var StrictQueryParams bool
+
+// rejectUnknownQueryParams responds with 400 listing every query parameter that is not
+// in allowed when strict mode is enabled. It returns true if the request was rejected.
+func rejectUnknownQueryParams(w http.ResponseWriter, r *http.Request, allowed ...string) bool {
+	if !StrictQueryParams {
+		return false
+	}
+
+	known := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		known[name] = true
+	}
+
+	var unknown []string
+	for name := range r.URL.Query() {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return false
+	}
+
+	sort.Strings(unknown)
+	writeJSONError(w, http.StatusBadRequest, "UNKNOWN_QUERY_PARAMETERS", "Unknown query parameters: "+strings.Join(unknown, ", "))
+	return true
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRejectUnknownQueryParams(t *testing.T) {
+	t.Cleanup(func() { StrictQueryParams = false })
+
+	tests := []struct {
+		name        string
+		strict      bool
+		query       string
+		wantReject  bool
+		wantMessage string
+	}{
+		{"lenient ignores unknown", false, "?days=30&sort=name", false, ""},
+		{"strict allows known", true, "?days=30&limit=5", false, ""},
+		{"strict allows none", true, "", false, ""},
+		{"strict lists unknown sorted", true, "?zeta=1&days=30&alpha=2", true, "Unknown query parameters: alpha, zeta"},
+		{"strict matches case", true, "?Days=30", true, "Unknown query parameters: Days"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			StrictQueryParams = tt.strict
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/employees/recent-hires"+tt.query, nil)
+
+			if got := rejectUnknownQueryParams(w, r, "days", "limit", "by"); got != tt.wantReject {
+				t.Fatalf("rejected = %t, want %t", got, tt.wantReject)
+			}
+			if !tt.wantReject {
+				if w.Body.Len() != 0 {
+					t.Errorf("wrote %q for an accepted request", w.Body.String())
+				}
+				return
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", w.Code)
+			}
+			if detail := decodeError(t, w); detail.Code != "UNKNOWN_QUERY_PARAMETERS" || detail.Message != tt.wantMessage {
+				t.Errorf("error = %+v, want UNKNOWN_QUERY_PARAMETERS %q", detail, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestStrictQueryParamsOnEndpoint(t *testing.T) {
+	StrictQueryParams = true
+	t.Cleanup(func() { StrictQueryParams = false })
+
+	w := httptest.NewRecorder()
+	GetEnums(w, httptest.NewRequest(http.MethodGet, "/api/enums?lang=th", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
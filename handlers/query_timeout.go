@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// QueryTimeout bounds the database work of a single request, zero or less disables it
+var QueryTimeout = 5 * time.Second
+
+// queryContext derives the context a handler runs its queries under, cancelled when the
+// client goes away or QueryTimeout elapses, whichever comes first
+func queryContext(r *http.Request) (context.Context, context.CancelFunc) {
+	if QueryTimeout <= 0 {
+		return context.WithCancel(r.Context())
+	}
+	return context.WithTimeout(r.Context(), QueryTimeout)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCancelledQueryIsDatabaseTimeout(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		return fakeResult{columns: employeeColumnNames, rows: [][]driver.Value{employeeRow(testEmployeeID)}}
+	})
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	for name, ctx := range map[string]context.Context{"cancelled": cancelled, "deadline exceeded": expired} {
+		r := httptest.NewRequest(http.MethodGet, "/api/employee/"+testEmployeeID, nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		EmployeeRouter(w, r)
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: status = %d, want 503", name, w.Code)
+		}
+		if detail := decodeError(t, w); detail.Code != "DATABASE_TIMEOUT" {
+			t.Errorf("%s: code = %q, want DATABASE_TIMEOUT", name, detail.Code)
+		}
+	}
+}
+
+func TestQueryContextTimeout(t *testing.T) {
+	previous := QueryTimeout
+	t.Cleanup(func() { QueryTimeout = previous })
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	QueryTimeout = time.Minute
+	ctx, cancel := queryContext(r)
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("deadline = %v, %t, want one within QueryTimeout", deadline, ok)
+	}
+	cancel()
+
+	QueryTimeout = 0
+	ctx, cancel = queryContext(r)
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("a zero QueryTimeout still set a deadline")
+	}
+	cancel()
+	if ctx.Err() == nil {
+		t.Error("the context is not cancelled by its cancel func")
+	}
+}
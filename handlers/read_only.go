@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"backend/middleware"
+)
+
+// ReadOnlyStatus reports whether read-only maintenance mode is enabled
+type ReadOnlyStatus struct {
+	Enabled bool `json:"enabled" example:"true"`
+}
+
+// ReadOnlyRouter dispatches requests for /api/admin/read-only by method
+func ReadOnlyRouter(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		GetReadOnlyMode(w, r)
+	case http.MethodPut:
+		SetReadOnlyMode(w, r)
+	default:
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	}
+}
+
+// GetReadOnlyMode godoc
+// @Summary Get read-only mode
+// @Description Report whether read-only maintenance mode is enabled
+// @Tags admin
+// @Produce json
+// @Success 200 {object} ReadOnlyStatus
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Security BearerAuth
+// @Router /admin/read-only [get]
+func GetReadOnlyMode(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, ReadOnlyStatus{Enabled: middleware.IsReadOnly()})
+}
+
+// SetReadOnlyMode godoc
+// @Summary Toggle read-only mode
+// @Description Turn read-only maintenance mode on or off without a restart. Requires an authenticated user and is refused when authentication is disabled. The toggle is accepted while read-only mode is on.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param status body ReadOnlyStatus true "New mode"
+// @Success 200 {object} ReadOnlyStatus
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 403 {object} ErrorResponse "Authentication is disabled"
+// @Failure 413 {object} ErrorResponse "Request body too large"
+// @Security BearerAuth
+// @Router /admin/read-only [put]
+func SetReadOnlyMode(w http.ResponseWriter, r *http.Request) {
+	// Without authentication anyone could take the service out of maintenance mode
+	if !AuthEnabled {
+		writeJSONError(w, http.StatusForbidden, "FORBIDDEN", "Toggling read-only mode requires authentication to be enabled")
+		return
+	}
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var status ReadOnlyStatus
+	if !decodeJSONBody(w, r, &status) {
+		return
+	}
+
+	middleware.SetReadOnly(status.Enabled)
+	log.Printf("Read-only mode set to %t by user %s", status.Enabled, userID)
+	writeJSON(w, http.StatusOK, status)
+}
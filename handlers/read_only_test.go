@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/middleware"
+)
+
+func TestSetReadOnlyModeRequiresAuth(t *testing.T) {
+	t.Cleanup(func() { AuthEnabled = false; middleware.SetReadOnly(false) })
+
+	tests := []struct {
+		name        string
+		authEnabled bool
+		want        int
+	}{
+		{"auth disabled", false, http.StatusForbidden},
+		{"no user", true, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		AuthEnabled = tt.authEnabled
+		w := httptest.NewRecorder()
+		ReadOnlyRouter(w, httptest.NewRequest(http.MethodPut, "/api/admin/read-only", strings.NewReader(`{"enabled":true}`)))
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+		if middleware.IsReadOnly() {
+			t.Errorf("%s: read-only mode was enabled", tt.name)
+		}
+	}
+}
+
+func TestSetReadOnlyModeToggles(t *testing.T) {
+	AuthEnabled = true
+	t.Cleanup(func() { AuthEnabled = false; middleware.SetReadOnly(false) })
+
+	for _, enabled := range []string{"true", "false"} {
+		r := httptest.NewRequest(http.MethodPut, "/api/admin/read-only", strings.NewReader(`{"enabled":`+enabled+`}`))
+		r = r.WithContext(middleware.WithUserID(r.Context(), "admin"))
+		w := httptest.NewRecorder()
+		ReadOnlyRouter(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("PUT enabled=%s: status %d, body %s", enabled, w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		ReadOnlyRouter(w, httptest.NewRequest(http.MethodGet, "/api/admin/read-only", nil))
+		if got := strings.TrimSpace(w.Body.String()); got != `{"enabled":`+enabled+`}` {
+			t.Errorf("GET after enabled=%s: body %s", enabled, got)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"backend/database"
+)
+
+type ReadinessStatus struct {
+	Status    string `json:"status"`
+	DB        string `json:"db"`
+	CheckedAt string `json:"checked_at"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Readiness reports whether the service can serve traffic, based on the most recent
+// background database ping. It is served outside /api for load balancers and probes.
+// It needs no authentication, so the ping error is only included when internal errors
+// are not masked; the monitor logs it either way.
+func Readiness(w http.ResponseWriter, r *http.Request) {
+	health := database.Status()
+
+	status := ReadinessStatus{Status: "ready", DB: "up"}
+	code := http.StatusOK
+	if !health.Healthy {
+		status = ReadinessStatus{Status: "not_ready", DB: "down"}
+		if !MaskInternalErrors {
+			status.Error = health.Error
+		}
+		code = http.StatusServiceUnavailable
+	}
+	if !health.CheckedAt.IsZero() {
+		status.CheckedAt = formatTimestamp(health.CheckedAt)
+	}
+
+	writeJSON(w, code, status)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/database"
+)
+
+type stubPinger struct{ err error }
+
+func (p stubPinger) PingContext(ctx context.Context) error { return p.err }
+
+func readiness(t *testing.T) (int, ReadinessStatus) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	Readiness(w, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+
+	var status ReadinessStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("decoding body %q: %v", w.Body.String(), err)
+	}
+	return w.Code, status
+}
+
+func TestReadinessHealthy(t *testing.T) {
+	database.CheckHealth(stubPinger{}, time.Second)
+
+	code, status := readiness(t)
+	if code != http.StatusOK || status.Status != "ready" || status.DB != "up" {
+		t.Errorf("got %d %+v, want 200 ready/up", code, status)
+	}
+}
+
+func TestReadinessUnhealthyError(t *testing.T) {
+	database.CheckHealth(stubPinger{err: errors.New("dial tcp 10.0.0.5:5432: connection refused")}, time.Second)
+	defer database.CheckHealth(stubPinger{}, time.Second)
+
+	tests := []struct {
+		masked    bool
+		wantError string
+	}{
+		{false, "dial tcp 10.0.0.5:5432: connection refused"},
+		{true, ""},
+	}
+
+	for _, tt := range tests {
+		MaskInternalErrors = tt.masked
+		code, status := readiness(t)
+		MaskInternalErrors = false
+
+		if code != http.StatusServiceUnavailable || status.Status != "not_ready" || status.DB != "down" {
+			t.Errorf("masked=%v: got %d %+v, want 503 not_ready/down", tt.masked, code, status)
+		}
+		if status.Error != tt.wantError {
+			t.Errorf("masked=%v: error = %q, want %q", tt.masked, status.Error, tt.wantError)
+		}
+	}
+}
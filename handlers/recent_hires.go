@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// recentHireColumns maps the accepted values of the "by" parameter to the date column they order by
+var recentHireColumns = map[string]string{
+	"created_at": "created_at",
+	"hire_date":  "hire_date",
+}
+
+// GetRecentHires godoc
+// @Summary Get recent hires
+// @Description Get active employees created or hired within the last N days, newest first. Hires dated in the future are not included.
+// @Tags employee
+// @Produce json
+// @Param days query int false "Window in days (1-365)" default(30)
+// @Param limit query int false "Maximum number of employees (1-100)" default(10)
+// @Param by query string false "Date to filter and order by: created_at or hire_date" default(created_at)
+// @Success 200 {array} Employee
+// @Failure 400 {object} ErrorResponse "Invalid days, limit or by"
+// @Failure 401 {object} ErrorResponse "Missing, malformed or expired token"
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Failure 500 {object} ErrorResponse "Error retrieving employees"
+// @Security BearerAuth
+// @Router /employees/recent-hires [get]
+func GetRecentHires(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if rejectUnknownQueryParams(w, r, "days", "limit", "by") {
+		return
+	}
+
+	days := 30
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 365 {
+			writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "days must be an integer between 1 and 365")
+			return
+		}
+		days = parsed
+	}
+
+	limit := 10
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 100 {
+			writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "limit must be an integer between 1 and 100")
+			return
+		}
+		limit = parsed
+	}
+
+	by := r.URL.Query().Get("by")
+	if by == "" {
+		by = "created_at"
+	}
+	column, ok := recentHireColumns[by]
+	if !ok {
+		writeJSONError(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETER", "by must be created_at or hire_date")
+		return
+	}
+
+	// Future-dated hires are scheduled, not recent. CURRENT_TIMESTAMP bounds both columns:
+	// a hire_date of today is midnight and included, while created_at keeps today's rows.
+	query := `SELECT ` + employeeColumns + ` FROM m_employee
+			  WHERE is_active = TRUE AND ` + column + ` >= CURRENT_DATE - $1::int
+			  AND ` + column + ` <= CURRENT_TIMESTAMP
+			  ORDER BY ` + column + ` DESC, id
+			  LIMIT $2`
+
+	ctx, cancel := queryContext(r)
+	defer cancel()
+
+	rows, err := DB.QueryContext(ctx, query, days, limit)
+	if err != nil {
+		writeDBError(w, r, err, "Error retrieving employees")
+		return
+	}
+	defer rows.Close()
+
+	employees := []Employee{}
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			writeDBError(w, r, err, "Error retrieving employees")
+			return
+		}
+		employees = append(employees, employee)
+	}
+	if err := rows.Err(); err != nil {
+		writeDBError(w, r, err, "Error retrieving employees")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, employees)
+}
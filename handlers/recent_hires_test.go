@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetRecentHires(t *testing.T) {
+	tests := []struct {
+		query    string
+		column   string
+		wantArgs []driver.Value
+	}{
+		{"", "created_at", []driver.Value{int64(30), int64(10)}},
+		{"?days=7&limit=3&by=hire_date", "hire_date", []driver.Value{int64(7), int64(3)}},
+	}
+	for _, tt := range tests {
+		var gotQuery string
+		var gotArgs []driver.Value
+		useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+			gotQuery, gotArgs = query, args
+			return fakeResult{columns: employeeColumnNames, rows: [][]driver.Value{employeeRow(testEmployeeID)}}
+		})
+
+		w := httptest.NewRecorder()
+		GetRecentHires(w, httptest.NewRequest(http.MethodGet, "/api/employees/recent-hires"+tt.query, nil))
+
+		var employees []Employee
+		if err := json.Unmarshal(w.Body.Bytes(), &employees); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%q: status = %d, body %s", tt.query, w.Code, w.Body.String())
+		}
+		if len(employees) != 1 || employees[0].ID != testEmployeeID {
+			t.Errorf("%q: employees = %+v", tt.query, employees)
+		}
+		if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+			t.Errorf("%q: args = %v, want %v", tt.query, gotArgs, tt.wantArgs)
+		}
+		for _, want := range []string{
+			tt.column + " >= CURRENT_DATE - $1::int",
+			tt.column + " <= CURRENT_TIMESTAMP",
+			"ORDER BY " + tt.column + " DESC",
+		} {
+			if !strings.Contains(gotQuery, want) {
+				t.Errorf("%q: query does not contain %q", tt.query, want)
+			}
+		}
+	}
+}
+
+func TestGetRecentHiresRejectsParameters(t *testing.T) {
+	for _, query := range []string{"?days=0", "?days=366", "?days=week", "?limit=0", "?limit=101", "?by=updated_at"} {
+		w := httptest.NewRecorder()
+		GetRecentHires(w, httptest.NewRequest(http.MethodGet, "/api/employees/recent-hires"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+		} else if detail := decodeError(t, w); detail.Code != "INVALID_QUERY_PARAMETER" {
+			t.Errorf("%s: code = %q", query, detail.Code)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// writeJSON writes payload as a JSON response with the given status. The payload is
+// encoded before anything is sent, so an encoding failure becomes a clean 500 instead
+// of a truncated body behind a success status. Failures are logged.
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		writeInternalError(w, "Error encoding response", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		log.Printf("[%s] Error writing response: %v", requestID(w), err)
+	}
+}
+
+// ErrorResponse is the body of every error the API returns
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail pairs a stable machine-readable code with a human-readable message,
+// and names the offending field, or lists every invalid field, when the error is about input
+type ErrorDetail struct {
+	Code      string       `json:"code" example:"EMPLOYEE_NOT_FOUND"`
+	Message   string       `json:"message" example:"Employee not found"`
+	Field     string       `json:"field,omitempty" example:"email"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty" example:"3f2b8c1e-9d4a-4e57-8b1f-6a0c2d9e7f10"`
+}
+
+// writeJSONError writes an error response in the shape shared by every endpoint
+func writeJSONError(w http.ResponseWriter, status int, code, message string) {
+	writeError(w, status, ErrorDetail{Code: code, Message: message})
+}
+
+// writeError writes detail as an error response, tagged with the request ID so a
+// client report can be matched to the server logs
+func writeError(w http.ResponseWriter, status int, detail ErrorDetail) {
+	detail.RequestID = requestID(w)
+	writeJSON(w, status, ErrorResponse{Error: detail})
+}
+
+// writeValidationErrors answers 400 listing every invalid field
+func writeValidationErrors(w http.ResponseWriter, errs []FieldError) {
+	writeError(w, http.StatusBadRequest, ErrorDetail{
+		Code:    "VALIDATION_FAILED",
+		Message: "One or more fields are invalid",
+		Fields:  errs,
+	})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/middleware"
+)
+
+// headerCounter records how many times WriteHeader is called on a recorder
+type headerCounter struct {
+	*httptest.ResponseRecorder
+	writes int
+}
+
+func (c *headerCounter) WriteHeader(status int) {
+	c.writes++
+	c.ResponseRecorder.WriteHeader(status)
+}
+
+func TestWriteJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeJSON(w, http.StatusCreated, map[string]string{"id": "1"})
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("status = %d, want 201", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	if got := w.Body.String(); got != "{\"id\":\"1\"}\n" {
+		t.Errorf("body = %q", got)
+	}
+}
+
+func TestWriteJSONEncodingFailure(t *testing.T) {
+	w := &headerCounter{ResponseRecorder: httptest.NewRecorder()}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"values": make(chan int)})
+
+	if w.writes != 1 {
+		t.Errorf("WriteHeader called %d times, want once", w.writes)
+	}
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+	if detail := decodeError(t, w.ResponseRecorder); detail.Code != "INTERNAL_ERROR" {
+		t.Errorf("error = %+v, want INTERNAL_ERROR", detail)
+	}
+}
+
+func TestErrorsCarryRequestID(t *testing.T) {
+	handler := middleware.RequestID(NotFound)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/unknown", nil)
+	r.Header.Set(middleware.RequestIDHeader, "client-abc-123")
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if detail := decodeError(t, w); detail.RequestID != "client-abc-123" {
+		t.Errorf("request_id = %q, want the ID from the request", detail.RequestID)
+	}
+}
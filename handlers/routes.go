@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isUUID reports whether s is a canonically formatted UUID
+func isUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+// employeePathParts splits the path after /api/employee/ into its segments,
+// e.g. /api/employee/123/emails/456 becomes [123 emails 456]
+func employeePathParts(r *http.Request) []string {
+	return strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/employee/"), "/"), "/")
+}
+
+// EmployeeRouter dispatches requests under /api/employee/ to the employee handler
+// or to one of the employee's sub-resources
+func EmployeeRouter(w http.ResponseWriter, r *http.Request) {
+	parts := employeePathParts(r)
+	if len(parts) < 2 {
+		GetEmployeeByID(w, r)
+		return
+	}
+
+	switch {
+	case parts[1] == "full" && len(parts) == 2 && r.Method == http.MethodGet:
+		GetEmployeeProfile(w, r)
+	case parts[1] == "full" && len(parts) == 2:
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	case parts[1] == "emails" && len(parts) == 2 && r.Method == http.MethodGet:
+		ListEmployeeEmails(w, r)
+	case parts[1] == "emails" && len(parts) == 2 && r.Method == http.MethodPost:
+		AddEmployeeEmail(w, r)
+	case parts[1] == "emails" && len(parts) == 3 && r.Method == http.MethodDelete:
+		DeleteEmployeeEmail(w, r)
+	case parts[1] == "emails" && len(parts) == 4 && parts[3] == "primary" && r.Method == http.MethodPut:
+		SetPrimaryEmployeeEmail(w, r)
+	case parts[1] == "emails" && (len(parts) <= 3 || len(parts) == 4 && parts[3] == "primary"):
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	case parts[1] == "documents" && len(parts) == 2 && r.Method == http.MethodGet:
+		ListEmployeeDocuments(w, r)
+	case parts[1] == "documents" && len(parts) == 2 && r.Method == http.MethodPost:
+		UploadEmployeeDocument(w, r)
+	case parts[1] == "documents" && len(parts) == 3 && r.Method == http.MethodGet:
+		DownloadEmployeeDocument(w, r)
+	case parts[1] == "documents" && len(parts) == 3 && r.Method == http.MethodDelete:
+		DeleteEmployeeDocument(w, r)
+	case parts[1] == "documents" && len(parts) <= 3:
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	default:
+		NotFound(w, r)
+	}
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/mail"
+	"time"
+)
+
+// Employment types stored in m_employee.employment_type
+const (
+	EmploymentTypeUnspecified = 0
+	EmploymentTypeFullTime    = 1
+	EmploymentTypePartTime    = 2
+	EmploymentTypeContract    = 3
+	EmploymentTypeIntern      = 4
+)
+
+// Genders stored in m_employee.gender
+const (
+	GenderUnspecified = 0
+	GenderMale        = 1
+	GenderFemale      = 2
+)
+
+// employmentTypeRequiredFields lists, per employment type, the fields an employee must
+// provide in addition to prefix_name, first_name and last_name. Field names must be
+// keys of requirableFields. Types without an entry have no extra requirements.
+var employmentTypeRequiredFields = map[int][]string{
+	EmploymentTypeFullTime: {"email", "phone_number", "birth_date", "hire_date", "department", "position"},
+	EmploymentTypePartTime: {"email", "phone_number", "hire_date", "department"},
+	EmploymentTypeContract: {"email", "hire_date"},
+	EmploymentTypeIntern:   {"email", "birth_date", "hire_date", "department"},
+}
+
+// missingEmploymentTypeFields returns the fields required for the employee's
+// employment type that have not been filled in
+func missingEmploymentTypeFields(employee Employee) []string {
+	var missing []string
+	for _, field := range employmentTypeRequiredFields[employee.EmploymentType] {
+		if requirableFields[field].missing(employee) {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// FieldError describes one invalid field of a request body
+type FieldError struct {
+	Field   string `json:"field" example:"first_name"`
+	Message string `json:"message" example:"is required"`
+}
+
+// validate checks every field of an employee about to be stored and returns all
+// problems found, so a client can fix them in one round trip. The email is expected
+// to be normalized already.
+func (e Employee) validate() []FieldError {
+	var errs []FieldError
+
+	for _, field := range []struct{ name, value string }{
+		{"prefix_name", e.PrefixName},
+		{"first_name", e.FirstName},
+		{"last_name", e.LastName},
+	} {
+		if field.value == "" {
+			errs = append(errs, FieldError{field.name, "is required"})
+		}
+	}
+
+	// Each employment type has its own set of additional required fields
+	for _, field := range missingEmploymentTypeFields(e) {
+		errs = append(errs, FieldError{field, "is required for this employment_type"})
+	}
+
+	// Coded fields must hold one of the values published by /api/enums
+	for _, field := range []struct {
+		name  string
+		value int
+	}{
+		{"employment_type", e.EmploymentType},
+		{"gender", e.Gender},
+	} {
+		if !enumAllows(field.name, field.value) {
+			errs = append(errs, FieldError{field.name, "must be one of " + enumValueList(field.name)})
+		}
+	}
+
+	if e.Email != "" {
+		if address, err := mail.ParseAddress(e.Email); err != nil || address.Address != e.Email {
+			errs = append(errs, FieldError{"email", "must be a valid email address"})
+		}
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"birth_date", e.BirthDate},
+		{"hire_date", e.HireDate},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", field.value); err != nil {
+			errs = append(errs, FieldError{field.name, "must be a date in YYYY-MM-DD format"})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEmployeeValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		employee Employee
+		want     []FieldError
+	}{
+		{
+			"valid unspecified type",
+			Employee{PrefixName: "Mr.", FirstName: "Somchai", LastName: "Jaidee"},
+			nil,
+		},
+		{
+			"valid contract",
+			Employee{PrefixName: "Ms.", FirstName: "Suda", LastName: "Dee", EmploymentType: EmploymentTypeContract, Email: "suda@example.com", HireDate: "2024-01-15"},
+			nil,
+		},
+		{
+			"missing names",
+			Employee{FirstName: "Somchai"},
+			[]FieldError{{"prefix_name", "is required"}, {"last_name", "is required"}},
+		},
+		{
+			"contract without its fields",
+			Employee{PrefixName: "Mr.", FirstName: "Somchai", LastName: "Jaidee", EmploymentType: EmploymentTypeContract},
+			[]FieldError{{"email", "is required for this employment_type"}, {"hire_date", "is required for this employment_type"}},
+		},
+		{
+			"bad email and dates",
+			Employee{PrefixName: "Mr.", FirstName: "Somchai", LastName: "Jaidee", Email: "Somchai <somchai@example.com>", BirthDate: "1990-13-01", HireDate: "15/01/2024"},
+			[]FieldError{
+				{"email", "must be a valid email address"},
+				{"birth_date", "must be a date in YYYY-MM-DD format"},
+				{"hire_date", "must be a date in YYYY-MM-DD format"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.employee.validate(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: validate() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCreateEmployeeReportsEveryInvalidField(t *testing.T) {
+	body := `{"first_name":"Somchai","email":"not-an-email","gender":7,"hire_date":"tomorrow"}`
+	w := httptest.NewRecorder()
+	CreateEmployee(w, httptest.NewRequest(http.MethodPost, "/api/employee", strings.NewReader(body)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	detail := decodeError(t, w)
+	if detail.Code != "VALIDATION_FAILED" {
+		t.Errorf("code = %q, want VALIDATION_FAILED", detail.Code)
+	}
+	want := []FieldError{
+		{"prefix_name", "is required"},
+		{"last_name", "is required"},
+		{"gender", "must be one of 0, 1, 2"},
+		{"email", "must be a valid email address"},
+		{"hire_date", "must be a date in YYYY-MM-DD format"},
+	}
+	if !reflect.DeepEqual(detail.Fields, want) {
+		t.Errorf("fields = %v, want %v", detail.Fields, want)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"runtime"
+
+	"backend/database"
+)
+
+// Build information, overridden at build time with
+// -ldflags "-X backend/handlers.Version=... -X backend/handlers.Commit=... -X backend/handlers.BuildTime=..."
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+type VersionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	// SchemaVersion is the latest applied migration, left out when the database cannot be read
+	SchemaVersion *int `json:"schema_version,omitempty"`
+}
+
+// GetVersion godoc
+// @Summary Get build information
+// @Description Get the application version, git commit, build time, Go version and database schema version of the running server
+// @Tags system
+// @Produce json
+// @Success 200 {object} VersionInfo
+// @Failure 405 {object} ErrorResponse "Method not allowed"
+// @Router /version [get]
+func GetVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	info := VersionInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	// Build information is still useful while the database is down, so a failed lookup only omits the schema version
+	ctx, cancel := queryContext(r)
+	defer cancel()
+	if version, err := database.SchemaVersion(ctx, DB); err != nil {
+		log.Printf("[%s] Error reading schema version: %v", requestID(w), err)
+	} else {
+		info.SchemaVersion = &version
+	}
+
+	writeJSON(w, http.StatusOK, info)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func getVersion(t *testing.T) VersionInfo {
+	t.Helper()
+	w := httptest.NewRecorder()
+	GetVersion(w, httptest.NewRequest(http.MethodGet, "/api/version", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	var info VersionInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatalf("decoding body %q: %v", w.Body.String(), err)
+	}
+	return info
+}
+
+func TestGetVersionReportsSchemaVersion(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		if !strings.Contains(query, "schema_migrations") {
+			t.Errorf("unexpected query %q", query)
+		}
+		return fakeResult{columns: []string{"max"}, rows: [][]driver.Value{{int64(6)}}}
+	})
+
+	info := getVersion(t)
+	if info.Version != Version || info.Commit != Commit || info.GoVersion != runtime.Version() {
+		t.Errorf("build information = %+v, want the package values", info)
+	}
+	if info.SchemaVersion == nil || *info.SchemaVersion != 6 {
+		t.Errorf("schema_version = %v, want 6", info.SchemaVersion)
+	}
+}
+
+func TestGetVersionWithoutDatabase(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) fakeResult {
+		return fakeResult{err: errors.New("connection refused")}
+	})
+
+	if info := getVersion(t); info.SchemaVersion != nil {
+		t.Errorf("schema_version = %d, want it omitted when the database is unreachable", *info.SchemaVersion)
+	}
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "backend/docs"
 
@@ -14,21 +18,153 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// @title IDS.Warp API
+// @version 1.0
+// @description API for managing employee for The Island digital solution Co., Ltd.
+// @termsOfService http://swagger.io/terms/
+
+// @contact.name API Support
+// @contact.email support@example.com
+
+// @license.name Apache 2.0
+// @license.url http://www.apache.org/licenses/LICENSE-2.0.html
+
+// @host localhost:8080
+// @BasePath /api
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Bearer token signed with JWT_SECRET, in the form "Bearer <jwt>"
 func main() {
 	// Initialize database connection
 	database.InitDB()
 	defer database.Close()
 
+	// Keep checking the connection in the background for the readiness probe
+	healthInterval := 15 * time.Second
+	if value := os.Getenv("DB_HEALTH_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatal("Invalid DB_HEALTH_INTERVAL:", err)
+		}
+		healthInterval = interval
+	}
+	if healthInterval <= 0 {
+		log.Println("Database health monitor disabled, /readiness reports the startup check only")
+	}
+	database.StartHealthMonitor(context.Background(), database.DB, healthInterval)
+
 	// Share database connection with handlers
 	handlers.DB = database.DB
+	handlers.StrictQueryParams = os.Getenv("STRICT_QUERY_PARAMS") == "true"
+	handlers.MaskInternalErrors = os.Getenv("APP_ENV") == "production"
+	if value := os.Getenv("DB_QUERY_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatal("Invalid DB_QUERY_TIMEOUT:", err)
+		}
+		handlers.QueryTimeout = timeout
+	}
+	middleware.SetReadOnly(os.Getenv("READ_ONLY_MODE") == "true")
+	if value := os.Getenv("DOCUMENT_MAX_BYTES"); value != "" {
+		maxBytes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Fatal("Invalid DOCUMENT_MAX_BYTES:", err)
+		}
+		handlers.DocumentMaxBytes = maxBytes
+	}
+	if value := os.Getenv("IMPORT_MAX_BYTES"); value != "" {
+		maxBytes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Fatal("Invalid IMPORT_MAX_BYTES:", err)
+		}
+		handlers.ImportMaxBytes = maxBytes
+	}
+	if value := os.Getenv("JSON_BODY_MAX_BYTES"); value != "" {
+		maxBytes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Fatal("Invalid JSON_BODY_MAX_BYTES:", err)
+		}
+		handlers.JSONBodyMaxBytes = maxBytes
+	}
+	if value := os.Getenv("DOCUMENT_ALLOWED_TYPES"); value != "" {
+		handlers.DocumentAllowedTypes = strings.Split(strings.ReplaceAll(value, " ", ""), ",")
+	}
+	if value := os.Getenv("ONBOARDING_REQUIRED_FIELDS"); value != "" {
+		err := handlers.SetOnboardingRequiredFields(strings.Split(strings.ReplaceAll(value, " ", ""), ","))
+		if err != nil {
+			log.Fatal("Invalid ONBOARDING_REQUIRED_FIELDS:", err)
+		}
+	}
 
 	// Setup routes
 	http.HandleFunc("/api/employee", middleware.EnableCORS(handlers.CreateEmployee))
-	http.HandleFunc("/api/employee/", middleware.EnableCORS(handlers.GetEmployeeByID))
+	http.HandleFunc("/api/employee/", middleware.EnableCORS(handlers.EmployeeRouter))
+	http.HandleFunc("/api/employees/export", middleware.EnableCORS(handlers.ExportEmployees))
+	http.HandleFunc("/api/employees/import", middleware.EnableCORS(handlers.ImportEmployees))
+	http.HandleFunc("/api/employees/cohort", middleware.EnableCORS(handlers.GetEmployeeCohort))
+	http.HandleFunc("/api/employees/cohorts", middleware.EnableCORS(handlers.GetEmployeeCohorts))
+	http.HandleFunc("/api/employees/recent-hires", middleware.EnableCORS(handlers.GetRecentHires))
+	http.HandleFunc("/api/employees/onboarding-incomplete", middleware.EnableCORS(handlers.GetOnboardingIncomplete))
+	http.HandleFunc("/api/employees/headcount-history", middleware.EnableCORS(handlers.GetHeadcountHistory))
+
+	http.HandleFunc("/api/enums", middleware.EnableCORS(handlers.GetEnums))
+	http.HandleFunc("/api/admin/read-only", middleware.EnableCORS(handlers.ReadOnlyRouter))
+	http.HandleFunc("/api/version", middleware.EnableCORS(handlers.GetVersion))
+
+	// Probe routes
+	http.HandleFunc("/readiness", handlers.Readiness)
+	http.HandleFunc("/api/health", handlers.HealthCheck)
 
 	// Swagger route
 	http.HandleFunc("/swagger/", httpSwagger.WrapHandler)
 
+	// Anything unmatched
+	http.HandleFunc("/", handlers.NotFound)
+
+	// Apply server-wide middleware
+	maxConcurrent := 0
+	if value := os.Getenv("MAX_CONCURRENT_REQUESTS"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			log.Fatal("Invalid MAX_CONCURRENT_REQUESTS:", err)
+		}
+		maxConcurrent = limit
+	}
+
+	requestTimeout := 30 * time.Second
+	if value := os.Getenv("REQUEST_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatal("Invalid REQUEST_TIMEOUT:", err)
+		}
+		requestTimeout = timeout
+	}
+
+	handler := middleware.ReadOnly("/api/admin/read-only")(http.DefaultServeMux.ServeHTTP)
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		handlers.AuthEnabled = true
+		// Docs, probes and build information stay reachable without a token
+		handler = middleware.RequireAuth([]byte(secret), "/swagger/", "/readiness", "/api/health", "/api/version")(handler)
+	} else {
+		log.Println("Warning: JWT_SECRET is not set, the API accepts unauthenticated requests")
+	}
+	if os.Getenv("ENFORCE_ACCEPT") == "true" {
+		// Every endpoint serves JSON or XML, only the export and document downloads serve anything else
+		served := []string{"application/json", "application/xml", "text/xml"}
+		routes := map[string][]string{
+			"GET /api/employees/export":                     {"application/x-ndjson"},
+			"GET /api/employee/{id}/documents/{documentId}": handlers.DocumentAllowedTypes,
+		}
+		handler = middleware.RequireAccept(served, routes, "/swagger/")(handler)
+	}
+	handler = middleware.StripTrailingSlash("/swagger/")(handler)
+	// Exports stream and imports run in one transaction, both take as long as the file needs
+	handler = middleware.Timeout(requestTimeout, "/api/employees/export", "/api/employees/import", "/swagger/")(handler)
+	handler = middleware.LimitConcurrency(maxConcurrent, "/readiness", "/api/health")(handler)
+	handler = middleware.RequestID(handler)
+
 	// Start server
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
@@ -37,5 +173,8 @@ func main() {
 	serverAddr := ":" + port
 	log.Printf("Server starting on port %s", serverAddr)
 	log.Printf("Swagger UI available at http://localhost%s/swagger/index.html", serverAddr)
-	log.Fatal(http.ListenAndServe(serverAddr, nil))
+	if middleware.IsReadOnly() {
+		log.Println("Read-only mode enabled, write requests will be rejected")
+	}
+	log.Fatal(http.ListenAndServe(serverAddr, handler))
 }
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RequireAccept returns a middleware that responds with 406 when the request's Accept
+// header rules out every one of the given media types. routes maps ServeMux patterns
+// such as "GET /api/employees/export" to the extra media types only that route serves.
+// Requests without an Accept header, and paths starting with one of the exempt
+// prefixes, are always served.
+func RequireAccept(types []string, routes map[string][]string, exempt ...string) func(http.HandlerFunc) http.HandlerFunc {
+	// A private mux resolves which route pattern, if any, a request belongs to
+	mux := http.NewServeMux()
+	routeTypes := make(map[string][]string, len(routes))
+	for pattern, extra := range routes {
+		mux.HandleFunc(pattern, func(http.ResponseWriter, *http.Request) {})
+		routeTypes[pattern] = append(append([]string{}, types...), extra...)
+	}
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if isExempt(r.URL.Path, exempt) {
+				next(w, r)
+				return
+			}
+
+			accept := r.Header.Get("Accept")
+			if accept == "" {
+				next(w, r)
+				return
+			}
+
+			allowed := types
+			if _, pattern := mux.Handler(r); pattern != "" {
+				allowed = routeTypes[pattern]
+			}
+			if acceptsAny(accept, allowed) {
+				next(w, r)
+				return
+			}
+
+			writeJSONError(w, http.StatusNotAcceptable, "NOT_ACCEPTABLE", "Not acceptable, this endpoint serves "+strings.Join(allowed, ", "))
+		}
+	}
+}
+
+// acceptsAny reports whether the Accept header allows at least one of the media types
+func acceptsAny(accept string, types []string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+				continue
+			}
+		}
+
+		for _, mediaType := range types {
+			if mediaRangeMatches(mediaRange, mediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mediaRangeMatches reports whether a media range such as */* or application/* covers the media type
+func mediaRangeMatches(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	if major, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(mediaType, major+"/")
+	}
+	return false
+}
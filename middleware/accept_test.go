@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireAccept(t *testing.T) {
+	handler := RequireAccept([]string{"application/json", "application/xml"}, nil, "/swagger/")(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		path, accept string
+		want         int
+	}{
+		{"/api/enums", "", http.StatusOK},
+		{"/api/enums", "application/json", http.StatusOK},
+		{"/api/enums", "application/xml; charset=utf-8", http.StatusOK},
+		{"/api/enums", "*/*", http.StatusOK},
+		{"/api/enums", "application/*", http.StatusOK},
+		{"/api/enums", "text/html, */*;q=0.8", http.StatusOK},
+		{"/api/enums", "text/html", http.StatusNotAcceptable},
+		{"/api/enums", "text/*", http.StatusNotAcceptable},
+		{"/api/enums", "application/json;q=0", http.StatusNotAcceptable},
+		{"/api/enums", "application/json;q=0, application/xml;q=0.0", http.StatusNotAcceptable},
+		{"/api/enums", "application/json;q=0, application/xml;q=0.5", http.StatusOK},
+		{"/api/enums", "not a media type", http.StatusNotAcceptable},
+		{"/swagger/index.html", "text/html", http.StatusOK},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%s with Accept %q: status = %d, want %d", tt.path, tt.accept, w.Code, tt.want)
+		}
+		if w.Code == http.StatusNotAcceptable && w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("%s with Accept %q: 406 is not JSON", tt.path, tt.accept)
+		}
+	}
+}
+
+func TestRequireAcceptRouteTypes(t *testing.T) {
+	routes := map[string][]string{
+		"GET /api/employees/export":                     {"application/x-ndjson"},
+		"GET /api/employee/{id}/documents/{documentId}": {"application/pdf", "image/png"},
+	}
+	handler := RequireAccept([]string{"application/json", "application/xml"}, routes)(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		method, path, accept string
+		want                 int
+	}{
+		{http.MethodGet, "/api/employees/export", "application/x-ndjson", http.StatusOK},
+		{http.MethodGet, "/api/employees/export", "application/json", http.StatusOK},
+		{http.MethodGet, "/api/employees/export", "image/png", http.StatusNotAcceptable},
+		{http.MethodGet, "/api/employee/e-1/documents/d-1", "image/png", http.StatusOK},
+		{http.MethodGet, "/api/employee/e-1/documents/d-1", "image/*", http.StatusOK},
+		{http.MethodGet, "/api/employee/e-1/documents/d-1", "application/x-ndjson", http.StatusNotAcceptable},
+		{http.MethodDelete, "/api/employee/e-1/documents/d-1", "image/png", http.StatusNotAcceptable},
+		{http.MethodGet, "/api/employee/e-1/documents", "image/png", http.StatusNotAcceptable},
+		{http.MethodGet, "/api/enums", "image/png", http.StatusNotAcceptable},
+		{http.MethodGet, "/api/enums", "application/x-ndjson", http.StatusNotAcceptable},
+		{http.MethodGet, "/api/enums", "application/json", http.StatusOK},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.path, nil)
+		r.Header.Set("Accept", tt.accept)
+		w := httptest.NewRecorder()
+		handler(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%s %s with Accept %q: status = %d, want %d", tt.method, tt.path, tt.accept, w.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("test-secret")
+
+// signToken builds a JWT from raw header and claims JSON, signed with secret using HS256
+func signToken(header, claims string, secret []byte) string {
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyToken(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	const hs256 = `{"alg":"HS256","typ":"JWT"}`
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"valid", signToken(hs256, `{"sub":"u-1","exp":1700000060,"nbf":1699999940}`, testSecret), ""},
+		{"valid without time claims", signToken(hs256, `{"sub":"u-1"}`, testSecret), ""},
+		{"too few segments", "abc.def", "Token is malformed"},
+		{"bad header encoding", "!!!.e30.sig", "Token header is malformed"},
+		{"alg none", signToken(`{"alg":"none"}`, `{"sub":"u-1"}`, testSecret), "Token must be signed with HS256"},
+		{"alg none unsigned", base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"u-1"}`)) + ".", "Token must be signed with HS256"},
+		{"bad signature", signToken(hs256, `{"sub":"u-1"}`, []byte("other-secret")), "Token signature is invalid"},
+		{"bad signature encoding", signToken(hs256, `{"sub":"u-1"}`, testSecret) + "!", "Token signature is malformed"},
+		{"expired", signToken(hs256, `{"sub":"u-1","exp":1700000000}`, testSecret), "Token has expired"},
+		{"not valid yet", signToken(hs256, `{"sub":"u-1","nbf":1700000001}`, testSecret), "Token is not valid yet"},
+		{"no subject", signToken(hs256, `{"exp":1700000060}`, testSecret), "Token does not identify a user"},
+		{"bad claims", signToken(hs256, `not json`, testSecret), "Token claims are malformed"},
+	}
+	for _, tt := range tests {
+		userID, err := verifyToken(tt.token, testSecret, now)
+		if tt.wantErr == "" {
+			if err != nil || userID != "u-1" {
+				t.Errorf("%s: got %q, %v, want u-1", tt.name, userID, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	valid := signToken(`{"alg":"HS256"}`, `{"sub":"u-1"}`, testSecret)
+	handler := RequireAuth(testSecret, "/api/health")(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := UserID(r.Context())
+		w.Header().Set("X-User", userID)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name, method, path, authorization string
+		want                              int
+		wantUser, wantMessage             string
+	}{
+		{"missing", http.MethodGet, "/api/enums", "", http.StatusUnauthorized, "", "Authorization header is required"},
+		{"wrong scheme", http.MethodGet, "/api/enums", "Basic dTpw", http.StatusUnauthorized, "", "Authorization scheme must be Bearer"},
+		{"empty token", http.MethodGet, "/api/enums", "Bearer ", http.StatusUnauthorized, "", "A bearer token is required"},
+		{"malformed", http.MethodGet, "/api/enums", "Bearer not-a-jwt", http.StatusUnauthorized, "", "Token is malformed"},
+		{"valid", http.MethodGet, "/api/enums", "Bearer " + valid, http.StatusOK, "u-1", ""},
+		{"lowercase bearer", http.MethodGet, "/api/enums", "bearer " + valid, http.StatusOK, "u-1", ""},
+		{"preflight", http.MethodOptions, "/api/enums", "", http.StatusOK, "", ""},
+		{"exempt", http.MethodGet, "/api/health", "", http.StatusOK, "", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+
+		if w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.want)
+		}
+		if got := w.Header().Get("X-User"); got != tt.wantUser {
+			t.Errorf("%s: user = %q, want %q", tt.name, got, tt.wantUser)
+		}
+		if w.Code != http.StatusUnauthorized {
+			continue
+		}
+		if w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: 401 without a WWW-Authenticate challenge", tt.name)
+		}
+		var body struct {
+			Error struct{ Message string } `json:"error"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error.Message != tt.wantMessage {
+			t.Errorf("%s: message = %q (%v), want %q", tt.name, body.Error.Message, err, tt.wantMessage)
+		}
+	}
+}
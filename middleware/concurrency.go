@@ -0,0 +1,32 @@
+package middleware
+
+import "net/http"
+
+// LimitConcurrency returns a middleware that allows at most limit requests to be
+// in flight at once, rejecting the rest with 503. Requests whose path starts with
+// one of the exempt prefixes are never limited. A limit of zero or less disables it.
+func LimitConcurrency(limit int, exempt ...string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		if limit <= 0 {
+			return next
+		}
+
+		slots := make(chan struct{}, limit)
+
+		return func(w http.ResponseWriter, r *http.Request) {
+			if isExempt(r.URL.Path, exempt) {
+				next(w, r)
+				return
+			}
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+				next(w, r)
+			default:
+				w.Header().Set("Retry-After", "1")
+				writeJSONError(w, http.StatusServiceUnavailable, "SERVER_BUSY", "Server is busy, please retry shortly")
+			}
+		}
+	}
+}
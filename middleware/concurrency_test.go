@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLimitConcurrency(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	handler := LimitConcurrency(1, "/readiness", "/api/health")(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/employee" {
+			entered <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Hold the only slot until the other requests have been checked
+	done := make(chan struct{})
+	go func() {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/employee", nil))
+		close(done)
+	}()
+	<-entered
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/api/enums", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503 while the limit is reached", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Retry-After = %q, want 1", got)
+	}
+	var body struct {
+		Error struct{ Code string } `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error.Code != "SERVER_BUSY" {
+		t.Errorf("body = %s, want a SERVER_BUSY error", w.Body.String())
+	}
+
+	for _, path := range []string{"/readiness", "/api/health"} {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status = %d, want exempt paths to pass while the limit is reached", path, w.Code)
+		}
+	}
+
+	close(release)
+	<-done
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/api/enums", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 once the slot is released", w.Code)
+	}
+}
+
+func TestLimitConcurrencyDisabled(t *testing.T) {
+	called := false
+	next := func(w http.ResponseWriter, r *http.Request) { called = true }
+	LimitConcurrency(0)(next)(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !called {
+		t.Error("a limit of zero should pass every request through")
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// writeJSONError writes an error in the same {"error":{"code","message"}} shape the handlers use,
+// including the request ID when RequestID has assigned one
+func writeJSONError(w http.ResponseWriter, status int, code, message string) {
+	detail := map[string]string{"code": code, "message": message}
+	if id := w.Header().Get(RequestIDHeader); id != "" {
+		detail["request_id"] = id
+	}
+	body, _ := json.Marshal(map[string]map[string]string{"error": detail})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+var readOnly atomic.Bool
+
+// SetReadOnly enables or disables read-only maintenance mode
+func SetReadOnly(enabled bool) {
+	readOnly.Store(enabled)
+}
+
+// IsReadOnly reports whether read-only maintenance mode is enabled
+func IsReadOnly() bool {
+	return readOnly.Load()
+}
+
+// ReadOnly returns a middleware that rejects write requests while read-only mode is
+// enabled. Requests whose path starts with one of the exempt prefixes, such as the
+// toggle that turns the mode off again, are always let through.
+func ReadOnly(exempt ...string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if readOnly.Load() && !isExempt(r.URL.Path, exempt) {
+				switch r.Method {
+				case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+					writeJSONError(w, http.StatusServiceUnavailable, "READ_ONLY_MODE", "Service is in read-only maintenance mode, writes are temporarily disabled")
+					return
+				}
+			}
+
+			next(w, r)
+		}
+	}
+}
+
+// isExempt reports whether path starts with one of the exempt prefixes
+func isExempt(path string, exempt []string) bool {
+	for _, prefix := range exempt {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadOnlyBlocksWritesAndAllowsReads(t *testing.T) {
+	SetReadOnly(true)
+	t.Cleanup(func() { SetReadOnly(false) })
+
+	handler := ReadOnly("/api/admin/read-only")(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/api/employee/1", http.StatusOK},
+		{http.MethodHead, "/api/employee/1", http.StatusOK},
+		{http.MethodOptions, "/api/employee", http.StatusOK},
+		{http.MethodPost, "/api/employee", http.StatusServiceUnavailable},
+		{http.MethodPut, "/api/employee/1/emails/2/primary", http.StatusServiceUnavailable},
+		{http.MethodPatch, "/api/employee/1", http.StatusServiceUnavailable},
+		{http.MethodDelete, "/api/employee/1/emails/2", http.StatusServiceUnavailable},
+		{http.MethodPut, "/api/admin/read-only", http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("%s %s: status %d, want %d", tt.method, tt.path, w.Code, tt.want)
+		}
+	}
+}
+
+func TestReadOnlyDisabledAllowsWrites(t *testing.T) {
+	SetReadOnly(false)
+
+	handler := ReadOnly()(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodPost, "/api/employee", nil))
+	if w.Code != http.StatusCreated {
+		t.Errorf("status %d, want %d", w.Code, http.StatusCreated)
+	}
+}
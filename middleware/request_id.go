@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// RequestIDHeader carries the ID that ties a request to its response and log lines
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDKey contextKey = "request_id"
+
+// maxRequestIDLength bounds client-supplied IDs so they cannot bloat logs
+const maxRequestIDLength = 128
+
+// RequestIDFromContext returns the ID stored by RequestID, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok && id != ""
+}
+
+// RequestID is a middleware that gives every request an ID, taken from the
+// X-Request-ID header when the client sent a usable one and generated otherwise.
+// The ID is stored in the request context and echoed in the response header.
+func RequestID(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next(w, r.WithContext(context.WithValue(r.Context(), requestIDKey, id)))
+	}
+}
+
+// validRequestID accepts short IDs of printable ASCII, which keeps them safe to log
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random version 4 UUID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unavailable"
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name, sent string
+		keep       bool
+	}{
+		{"round trip", "client-abc-123", true},
+		{"absent", "", false},
+		{"contains space", "client abc", false},
+		{"non ascii", "ไอดี", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+		{"longest kept", strings.Repeat("a", maxRequestIDLength), true},
+	}
+	for _, tt := range tests {
+		var seen string
+		handler := RequestID(func(w http.ResponseWriter, r *http.Request) {
+			seen, _ = RequestIDFromContext(r.Context())
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/api/enums", nil)
+		if tt.sent != "" {
+			r.Header.Set(RequestIDHeader, tt.sent)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+
+		got := w.Header().Get(RequestIDHeader)
+		if got != seen {
+			t.Errorf("%s: header %q differs from context %q", tt.name, got, seen)
+		}
+		if tt.keep && got != tt.sent {
+			t.Errorf("%s: ID = %q, want the client's ID echoed", tt.name, got)
+		}
+		if !tt.keep && !uuidV4.MatchString(got) {
+			t.Errorf("%s: ID = %q, want a fresh UUID", tt.name, got)
+		}
+	}
+}
+
+func TestRequestIDIsUniquePerRequest(t *testing.T) {
+	handler := RequestID(func(w http.ResponseWriter, r *http.Request) {})
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		id := w.Header().Get(RequestIDHeader)
+		if seen[id] {
+			t.Fatalf("ID %q generated twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestRequestIDInErrorBody(t *testing.T) {
+	handler := RequestID(func(w http.ResponseWriter, r *http.Request) {
+		writeJSONError(w, http.StatusServiceUnavailable, "SERVER_BUSY", "Server is busy")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/enums", nil)
+	r.Header.Set(RequestIDHeader, "client-abc-123")
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	var body struct {
+		Error struct {
+			RequestID string `json:"request_id"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding body %q: %v", w.Body.String(), err)
+	}
+	if body.Error.RequestID != "client-abc-123" {
+		t.Errorf("request_id = %q, want client-abc-123", body.Error.RequestID)
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Timeout returns a middleware that cancels the request context after d and
+// responds with 504 if the handler has not finished by then. Requests whose path
+// starts with one of the exempt prefixes, such as streamed exports, are not limited.
+// A duration of zero or less disables it.
+func Timeout(d time.Duration, exempt ...string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		if d <= 0 {
+			return next
+		}
+
+		return func(w http.ResponseWriter, r *http.Request) {
+			if isExempt(r.URL.Path, exempt) {
+				next(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			// Start from the headers outer middleware has set, such as the request ID
+			tw := &timeoutWriter{header: w.Header().Clone()}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				next(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				for key, values := range tw.header {
+					w.Header()[key] = values
+				}
+				if tw.status == 0 {
+					tw.status = http.StatusOK
+				}
+				w.WriteHeader(tw.status)
+				w.Write(tw.body.Bytes())
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				tw.timedOut = true
+				writeJSONError(w, http.StatusGatewayTimeout, "REQUEST_TIMEOUT", "Request exceeded the "+d.String()+" time limit")
+			}
+		}
+	}
+}
+
+// timeoutWriter buffers a handler's response so it can be discarded on timeout
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	status   int
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.body.Write(b)
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.status != 0 {
+		return
+	}
+	tw.status = status
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutAnswers504(t *testing.T) {
+	handler := RequestID(Timeout(10 * time.Millisecond)(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("too late"))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/employees/cohorts", nil)
+	r.Header.Set(RequestIDHeader, "req-timeout")
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want 504", w.Code)
+	}
+	if got := w.Header().Get(RequestIDHeader); got != "req-timeout" {
+		t.Errorf("%s = %q, want the outer request ID", RequestIDHeader, got)
+	}
+	var body struct {
+		Error struct {
+			Code      string `json:"code"`
+			RequestID string `json:"request_id"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding body %q: %v", w.Body.String(), err)
+	}
+	if body.Error.Code != "REQUEST_TIMEOUT" || body.Error.RequestID != "req-timeout" {
+		t.Errorf("error = %+v, want REQUEST_TIMEOUT with the request ID", body.Error)
+	}
+}
+
+func TestTimeoutPassesResponseThrough(t *testing.T) {
+	handler := RequestID(Timeout(time.Second)(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/employee", nil)
+	r.Header.Set(RequestIDHeader, "req-fast")
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if w.Code != http.StatusCreated || w.Body.String() != `{"id":"1"}` {
+		t.Errorf("response = %d %q, want the handler's 201 body", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want the handler's header", got)
+	}
+	if got := w.Header().Get(RequestIDHeader); got != "req-fast" {
+		t.Errorf("%s = %q, want it kept", RequestIDHeader, got)
+	}
+}
+
+func TestTimeoutReraisesPanic(t *testing.T) {
+	handler := Timeout(time.Second)(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("recovered %v, want the handler's panic", p)
+		}
+	}()
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/enums", nil))
+	t.Error("panic was swallowed")
+}
+
+func TestTimeoutExemptPath(t *testing.T) {
+	handler := Timeout(time.Millisecond, "/api/employees/export")(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("exempt request has a deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/api/employees/export", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 for an exempt path", w.Code)
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// StripTrailingSlash returns a middleware that removes trailing slashes from the
+// request path before routing, so /api/employees/ is served the same as
+// /api/employees. Paths starting with one of the exempt prefixes are left alone.
+func StripTrailingSlash(exempt ...string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			path := r.URL.Path
+			if path == "/" || !strings.HasSuffix(path, "/") {
+				next(w, r)
+				return
+			}
+
+			if isExempt(path, exempt) {
+				next(w, r)
+				return
+			}
+
+			r2 := new(http.Request)
+			*r2 = *r
+			r2.URL = new(url.URL)
+			*r2.URL = *r.URL
+			r2.URL.Path = strings.TrimRight(path, "/")
+			if r2.URL.Path == "" {
+				r2.URL.Path = "/"
+			}
+			r2.URL.RawPath = ""
+
+			next(w, r2)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/handlers"
+	"backend/middleware"
+)
+
+func TestStripTrailingSlashServesSameRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	for _, pattern := range []string{"/api/employees/cohorts", "/api/enums", "/swagger/", "/"} {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Route", pattern)
+			w.Header().Set("X-Path", r.URL.Path)
+		})
+	}
+	handler := middleware.StripTrailingSlash("/swagger/")(mux.ServeHTTP)
+
+	tests := []struct {
+		path, wantRoute, wantPath string
+	}{
+		{"/api/enums", "/api/enums", "/api/enums"},
+		{"/api/enums/", "/api/enums", "/api/enums"},
+		{"/api/employees/cohorts//", "/api/employees/cohorts", "/api/employees/cohorts"},
+		{"/", "/", "/"},
+		{"/swagger/", "/swagger/", "/swagger/"},
+		{"/swagger/index.html", "/swagger/", "/swagger/index.html"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if route, path := w.Header().Get("X-Route"), w.Header().Get("X-Path"); route != tt.wantRoute || path != tt.wantPath {
+			t.Errorf("%s: served by %s as %s, want %s as %s", tt.path, route, path, tt.wantRoute, tt.wantPath)
+		}
+	}
+}
+
+func TestStripTrailingSlashEmployeeRoutes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/employee", handlers.CreateEmployee)
+	mux.HandleFunc("/api/employee/", handlers.EmployeeRouter)
+	handler := middleware.StripTrailingSlash()(mux.ServeHTTP)
+
+	// With the slash stripped, /api/employee/ reaches the create endpoint, which only takes POST
+	for _, path := range []string{"/api/employee", "/api/employee/"} {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("GET %s: status = %d, want 405", path, w.Code)
+		}
+	}
+
+	// A trailing slash after an ID is routed like the ID alone
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/api/employee/not-a-uuid/", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GET /api/employee/not-a-uuid/: status = %d, want 400", w.Code)
+	}
+}